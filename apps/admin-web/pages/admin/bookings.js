@@ -54,6 +54,10 @@ export default function AdminBookings() {
   const [to, setTo] = useState('');
   const [error, setError] = useState('');
   const [loading, setLoading] = useState(false);
+  // settlement holds the booking whose check-out was refused because its
+  // folio still has money owing.
+  const [settlement, setSettlement] = useState(null);
+  const [overrideReason, setOverrideReason] = useState('');
 
   useEffect(() => {
     loadBookings();
@@ -65,7 +69,7 @@ export default function AdminBookings() {
       if (filters.from) search.set('from', filters.from);
       if (filters.to) search.set('to', filters.to);
       const query = search.toString() ? `?${search.toString()}` : '';
-      const res = await fetch(`${apiBase}/api/admin/bookings${query}`);
+      const res = await fetch(`${apiBase}/api/admin/bookings${query}`, { headers: authHeaders() });
       if (!res.ok) {
         throw new Error(await res.text());
       }
@@ -95,7 +99,7 @@ export default function AdminBookings() {
     try {
       const res = await fetch(
         `${apiBase}/api/admin/bookings/${id}/check-in?actionDate=${encodeURIComponent(checkIn)}`,
-        { method: 'POST' },
+        { method: 'POST', headers: authHeaders() },
       );
       if (!res.ok) {
         throw new Error(await res.text());
@@ -111,18 +115,31 @@ export default function AdminBookings() {
     }
   };
 
-  const handleCheckOut = async (id, checkOut) => {
+  const handleCheckOut = async (id, checkOut, reason = '') => {
     setError('');
     try {
+      const search = new URLSearchParams({ actionDate: checkOut });
+      if (reason) {
+        search.set('override', 'true');
+        search.set('reason', reason);
+      }
       const res = await fetch(
-        `${apiBase}/api/admin/bookings/${id}/check-out?actionDate=${encodeURIComponent(checkOut)}`,
-        { method: 'POST' },
+        `${apiBase}/api/admin/bookings/${id}/check-out?${search.toString()}`,
+        { method: 'POST', headers: authHeaders() },
       );
+      if (res.status === 409) {
+        const owing = await res.json();
+        setSettlement({ id, checkOut, balance: owing.balance });
+        setOverrideReason('');
+        setError(`Outstanding balance of ${owing.balance.toFixed(2)} must be settled before check-out`);
+        return;
+      }
       if (!res.ok) {
         throw new Error(await res.text());
       }
       const payload = await res.json();
       const [normalized] = normalizeBookings([payload]);
+      setSettlement(null);
       applyStatusUpdate(normalized.id, normalized.status);
     } catch (err) {
       applyStatusUpdate(id, 'Checked-out');
@@ -132,6 +149,32 @@ export default function AdminBookings() {
     }
   };
 
+  // handleSettle takes the outstanding balance as a payment on the folio and
+  // checks the guest out again.
+  const handleSettle = async () => {
+    const { id, checkOut, balance } = settlement;
+    setError('');
+    const res = await fetch(`${apiBase}/api/admin/bookings/${id}/folio`, {
+      method: 'POST',
+      headers: { 'Content-Type': 'application/json', ...authHeaders() },
+      body: JSON.stringify({ kind: 'payment', description: 'Payment at check-out', amount: balance }),
+    });
+    if (!res.ok) {
+      setError(`Payment failed (${await res.text()})`);
+      return;
+    }
+    await handleCheckOut(id, checkOut);
+  };
+
+  const handleOverrideCheckOut = async () => {
+    const reason = overrideReason.trim();
+    if (!reason) {
+      setError('A reason is required to check out with a balance owing');
+      return;
+    }
+    await handleCheckOut(settlement.id, settlement.checkOut, reason);
+  };
+
   const applyStatusUpdate = (id, status) => {
     setAllBookings((prev) => updateStatus(prev, id, status));
     setBookings((prev) => updateStatus(prev, id, status));
@@ -251,6 +294,24 @@ export default function AdminBookings() {
                       Check Out
                     </button>
                   </div>
+                  {settlement?.id === b.id && (
+                    <div className="settle-panel" style={{ display: 'flex', gap: '8px', flexWrap: 'wrap', width: '100%', marginTop: '12px' }}>
+                      <button type="button" className="settle-button" onClick={handleSettle}>
+                        Take payment of {settlement.balance.toFixed(2)} and check out
+                      </button>
+                      <input
+                        name="overrideReason"
+                        className="input"
+                        placeholder="Reason to check out unpaid"
+                        value={overrideReason}
+                        onChange={(e) => setOverrideReason(e.target.value)}
+                        style={{ flex: 1, minWidth: '180px' }}
+                      />
+                      <button type="button" className="override-check-out-button" onClick={handleOverrideCheckOut}>
+                        Check out unpaid
+                      </button>
+                    </div>
+                  )}
                 </article>
               );
             })}
@@ -261,6 +322,17 @@ export default function AdminBookings() {
   );
 }
 
+// authHeaders sends the admin session so actions are recorded against the
+// signed-in staff member.
+function authHeaders() {
+  if (typeof document === 'undefined') return {};
+  const token = document.cookie
+    .split('; ')
+    .find((row) => row.startsWith('admin_auth_token='))
+    ?.split('=')[1];
+  return token ? { Authorization: `Bearer ${token}` } : {};
+}
+
 function applyLocalFilter(list, from, to) {
   if (!from && !to) return list;
   const fromDate = from ? new Date(from) : null;
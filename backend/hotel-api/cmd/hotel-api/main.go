@@ -2,57 +2,165 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"log"
+	"net"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	authhttp "github.com/yourorg/hotel-api/internal/auth/adapters/http"
 	authapp "github.com/yourorg/hotel-api/internal/auth/app"
 	bookinghttp "github.com/yourorg/hotel-api/internal/booking/adapters/http"
+	"github.com/yourorg/hotel-api/internal/booking/adapters/payment"
 	bookingapp "github.com/yourorg/hotel-api/internal/booking/app"
+	bookingdomain "github.com/yourorg/hotel-api/internal/booking/domain"
+	propertyhttp "github.com/yourorg/hotel-api/internal/property/adapters/http"
+	propertyapp "github.com/yourorg/hotel-api/internal/property/app"
+	propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
 	roomhttp "github.com/yourorg/hotel-api/internal/room/adapters/http"
 	roomapp "github.com/yourorg/hotel-api/internal/room/app"
+	roomdomain "github.com/yourorg/hotel-api/internal/room/domain"
 	"github.com/yourorg/hotel-api/internal/seed"
 )
 
 func main() {
 	ctx := context.Background()
 	store := seed.NewInMemoryStore()
-	seeder := seed.NewSeeder(store, store, store)
-
-	if len(os.Args) > 1 && os.Args[1] == "seed" {
-		if err := seeder.Seed(ctx); err != nil {
-			log.Fatalf("seed failed: %v", err)
-		}
-		log.Println("seed completed")
-		return
-	}
+	bookingCfg := bookingConfigFromEnv()
+	clock := propertyapp.NewBusinessDateService(bookingCfg.Property.Location(), os.Getenv("BUSINESS_DATE_TEST_MODE") == "true")
+	seeder := seed.NewSeeder(store, store, store, store, store, clock)
 
 	if err := seeder.Seed(ctx); err != nil {
 		log.Fatalf("seed failed: %v", err)
 	}
 
-	authSvc := authapp.NewService(store, authapp.PlainPasswordChecker{}, authapp.NewStaticTokenIssuer("hotel-api"))
-	roomSearchSvc := roomapp.NewSearchService(store, store)
-	bookingSvc := bookingapp.NewService(store, store)
-	adminRoomSvc := roomapp.NewAdminService(store, store)
+	authSvc := authapp.NewService(store, store, authapp.PlainPasswordChecker{}, authapp.NewStaticTokenIssuer("hotel-api"))
+	roomSearchSvc := roomapp.NewSearchService(store, store, store, store, store, store, store, store, clock)
+	bookingSvc := bookingapp.NewService(store, store, store, store, store, store, store, store, store, store, store, store, payment.NewFakeGateway(floatFromEnv("PAYMENT_DECLINE_ABOVE", 0)), store, clock, bookingCfg)
+	adminRoomSvc := roomapp.NewAdminService(store, store, clock)
+	restrictionSvc := roomapp.NewRestrictionService(store)
+	rateSvc := roomapp.NewRateService(store, store, clock)
+	ratePlanSvc := roomapp.NewRatePlanService(store, clock)
+	promoSvc := roomapp.NewPromoService(store)
+	occupancySvc := roomapp.NewOccupancyService(store)
 	adminBookingHandler := bookinghttp.NewAdminHandler(bookingSvc)
 
+	if limit := intFromEnv("OVERBOOKING_DEFAULT", 0); limit > 0 {
+		if err := bookingSvc.SetOverbookingLimit(ctx, bookingdomain.OverbookingLimit{Limit: limit}); err != nil {
+			log.Fatalf("overbooking setup failed: %v", err)
+		}
+	}
+
+	if err := restrictionSvc.SetHorizon(ctx, horizonFromEnv()); err != nil {
+		log.Fatalf("booking horizon setup failed: %v", err)
+	}
+
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "seed":
+			log.Println("seed completed")
+			return
+		case "no-shows":
+			processed, err := bookingSvc.ProcessNoShows(ctx)
+			if err != nil {
+				log.Fatalf("no-show processing failed: %v", err)
+			}
+			log.Printf("no-show processing completed: %d bookings marked", len(processed))
+			return
+		case "night-audit":
+			// An optional YYYY-MM-DD argument audits that day instead of
+			// the current business date, as ?date= does on the jobs API.
+			var date propertydomain.Date
+			if len(os.Args) > 2 {
+				d, err := propertydomain.ParseDate(os.Args[2])
+				if err != nil {
+					log.Fatalf("night audit failed: %v", err)
+				}
+				date = d
+			}
+			report, err := bookingSvc.NightAudit(ctx, date, bookingapp.ChangeMeta{})
+			if err != nil {
+				log.Fatalf("night audit failed: %v", err)
+			}
+			log.Printf("night audit completed for %s: %d room nights posted, %d no-shows, occupancy %.2f%%",
+				report.Date, report.RoomNightsPosted, len(report.NoShows), report.Occupancy)
+			return
+		default:
+			log.Fatalf("unknown command %q", os.Args[1])
+		}
+	}
+
+	// Jobs also run on a schedule while serving, and on demand through
+	// /api/admin/jobs.
+	go runEvery(ctx, durationFromEnv("DEPOSIT_INTERVAL", time.Hour), func() {
+		processed, err := bookingSvc.ProcessOverdueDeposits(ctx)
+		if err != nil {
+			log.Printf("deposit processing failed: %v", err)
+			return
+		}
+		if len(processed) > 0 {
+			log.Printf("%d bookings have overdue deposits", len(processed))
+		}
+	})
+	go runEvery(ctx, durationFromEnv("NO_SHOW_INTERVAL", 15*time.Minute), func() {
+		processed, err := bookingSvc.ProcessNoShows(ctx)
+		if err != nil {
+			log.Printf("no-show processing failed: %v", err)
+			return
+		}
+		if len(processed) > 0 {
+			log.Printf("marked %d bookings as no-show", len(processed))
+		}
+	})
+
+	// The business date only moves when a day is audited. Test mode pins
+	// the clock, so acceptance tests run the audit through the API instead.
+	if !clock.Status().TestMode {
+		go runDaily(ctx, clockFromEnv("NIGHT_AUDIT_TIME", 2*time.Hour), clock.Location(), func() {
+			closed, err := bookingSvc.CloseOpenDays(ctx, bookingapp.ChangeMeta{})
+			for _, report := range closed {
+				log.Printf("night audit closed %s", report.Date)
+			}
+			if err != nil {
+				log.Printf("night audit failed: %v", err)
+			}
+		})
+	}
+
 	mux := http.NewServeMux()
 	mux.Handle("/api/auth/login", authhttp.NewLoginHandler(authSvc))
 	mux.Handle("/api/admin/auth/login", authhttp.NewLoginHandler(authSvc))
 	mux.Handle("/api/guest/rooms/search", roomhttp.NewSearchHandler(roomSearchSvc))
 	mux.Handle("/api/admin/rooms", roomhttp.NewAdminHandler(adminRoomSvc))
 	mux.Handle("/api/admin/rooms/", roomhttp.NewAdminHandler(adminRoomSvc))
+	mux.Handle("/api/admin/restrictions", roomhttp.NewRestrictionHandler(restrictionSvc))
+	mux.Handle("/api/admin/rates", roomhttp.NewRateHandler(rateSvc))
+	mux.Handle("/api/admin/rates/", roomhttp.NewRateHandler(rateSvc))
+	mux.Handle("/api/admin/rate-plans", roomhttp.NewRatePlanHandler(ratePlanSvc))
+	mux.Handle("/api/admin/rate-plans/", roomhttp.NewRatePlanHandler(ratePlanSvc))
+	mux.Handle("/api/admin/promos", roomhttp.NewPromoHandler(promoSvc))
+	mux.Handle("/api/admin/promos/", roomhttp.NewPromoHandler(promoSvc))
+	mux.Handle("/api/admin/occupancy", roomhttp.NewOccupancyHandler(occupancySvc))
 	mux.Handle("/api/guest/bookings", bookinghttp.NewHandler(bookingSvc))
 	mux.Handle("/api/guest/bookings/", bookinghttp.NewHandler(bookingSvc))
+	mux.Handle("/api/guest/bookings/lookup", bookinghttp.NewLookupHandler(bookingSvc, intFromEnv("LOOKUP_RATE_LIMIT", 10), time.Minute, networksFromEnv("TRUSTED_PROXIES")))
+	mux.Handle("/api/guest/calendar", bookinghttp.NewCalendarHandler(bookingSvc))
+	mux.Handle("/api/guest/calendar/", bookinghttp.NewCalendarHandler(bookingSvc))
 	mux.Handle("/api/admin/bookings", adminBookingHandler)
 	mux.Handle("/api/admin/bookings/", adminBookingHandler)
+	mux.Handle("/api/admin/jobs/", bookinghttp.NewJobsHandler(bookingSvc))
+	mux.Handle("/api/admin/overbooking", bookinghttp.NewOverbookingHandler(bookingSvc))
+	mux.Handle("/api/admin/reports/", bookinghttp.NewReportsHandler(bookingSvc))
+	mux.Handle("/api/admin/business-date", propertyhttp.NewBusinessDateHandler(clock))
+	mux.Handle("/api/admin/business-date/", propertyhttp.NewBusinessDateHandler(clock))
 
 	addr := ":" + envOrDefault("PORT", "8080")
 	server := &http.Server{
 		Addr:    addr,
-		Handler: withCORS(mux),
+		Handler: withCORS(authhttp.Authenticate(authSvc, mux)),
 	}
 
 	log.Printf("hotel-api listening on %s", addr)
@@ -68,6 +176,196 @@ func envOrDefault(key, fallback string) string {
 	return fallback
 }
 
+func durationFromEnv(key string, fallback time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		log.Fatalf("invalid %s: %v", key, err)
+	}
+	return d
+}
+
+func intFromEnv(key string, fallback int) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		log.Fatalf("invalid %s: %v", key, err)
+	}
+	return n
+}
+
+func floatFromEnv(key string, fallback float64) float64 {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		log.Fatalf("invalid %s: %v", key, err)
+	}
+	return f
+}
+
+// clockFromEnv parses an "HH:MM" time of day into an offset from midnight.
+func clockFromEnv(key string, fallback time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	t, err := time.Parse("15:04", v)
+	if err != nil {
+		log.Fatalf("invalid %s: %v", key, err)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+}
+
+// networksFromEnv parses a comma-separated list of IP addresses and CIDR
+// ranges, such as "10.0.0.0/8,192.168.1.5".
+func networksFromEnv(key string) []*net.IPNet {
+	var networks []*net.IPNet
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		if !strings.Contains(value, "/") {
+			if ip := net.ParseIP(value); ip != nil && ip.To4() != nil {
+				value += "/32"
+			} else {
+				value += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			log.Fatalf("invalid %s: %v", key, err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
+
+// locationFromEnv reads an IANA timezone name such as "Europe/Berlin";
+// unset means UTC.
+func locationFromEnv(key string) *time.Location {
+	value := os.Getenv(key)
+	if value == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(value)
+	if err != nil {
+		log.Fatalf("invalid %s: %v", key, err)
+	}
+	return loc
+}
+
+// depositRulesFromEnv parses a JSON list of deposit rules, for example
+// [{"minNights":7,"percent":0.3,"balanceDueDays":14}].
+func depositRulesFromEnv(key string) []bookingdomain.DepositRule {
+	v := os.Getenv(key)
+	if v == "" {
+		return nil
+	}
+	var specs []struct {
+		RoomType       string  `json:"roomType"`
+		MinNights      int     `json:"minNights"`
+		Percent        float64 `json:"percent"`
+		Nights         int     `json:"nights"`
+		DueDays        int     `json:"dueDays"`
+		BalanceDueDays int     `json:"balanceDueDays"`
+	}
+	if err := json.Unmarshal([]byte(v), &specs); err != nil {
+		log.Fatalf("invalid %s: %v", key, err)
+	}
+	rules := make([]bookingdomain.DepositRule, 0, len(specs))
+	for _, spec := range specs {
+		rules = append(rules, bookingdomain.DepositRule(spec))
+	}
+	return rules
+}
+
+// horizonFromEnv reads how soon and how far ahead guests can book.
+func horizonFromEnv() roomdomain.BookingHorizon {
+	h := roomdomain.DefaultBookingHorizon()
+	h.MinLeadDays = intFromEnv("BOOKING_MIN_LEAD_DAYS", h.MinLeadDays)
+	h.MaxAdvanceDays = intFromEnv("BOOKING_MAX_ADVANCE_DAYS", h.MaxAdvanceDays)
+	h.SameDayCutoff = clockFromEnv("SAME_DAY_CUTOFF", h.SameDayCutoff)
+	return h
+}
+
+func bookingConfigFromEnv() bookingapp.Config {
+	cfg := bookingapp.DefaultConfig()
+	cfg.Property.Name = envOrDefault("PROPERTY_NAME", cfg.Property.Name)
+	cfg.Property.Address = envOrDefault("PROPERTY_ADDRESS", cfg.Property.Address)
+	cfg.Property.Phone = envOrDefault("PROPERTY_PHONE", cfg.Property.Phone)
+	cfg.Property.Email = envOrDefault("PROPERTY_EMAIL", cfg.Property.Email)
+	cfg.Property.TaxID = envOrDefault("PROPERTY_TAX_ID", cfg.Property.TaxID)
+	cfg.Property.InvoicePrefix = envOrDefault("INVOICE_PREFIX", cfg.Property.InvoicePrefix)
+	cfg.Property.Timezone = locationFromEnv("PROPERTY_TIMEZONE")
+	cfg.NoShow.Cutoff = clockFromEnv("NO_SHOW_CUTOFF", cfg.NoShow.Cutoff)
+	cfg.NoShow.Fee = floatFromEnv("NO_SHOW_FEE", cfg.NoShow.Fee)
+	cfg.Stay.CheckInTime = clockFromEnv("CHECK_IN_TIME", cfg.Stay.CheckInTime)
+	cfg.Stay.CheckOutTime = clockFromEnv("CHECK_OUT_TIME", cfg.Stay.CheckOutTime)
+	cfg.Stay.EarlyCheckInFee = floatFromEnv("EARLY_CHECK_IN_FEE_NIGHTS", cfg.Stay.EarlyCheckInFee)
+	cfg.Stay.LateCheckOutFee = floatFromEnv("LATE_CHECK_OUT_FEE_NIGHTS", cfg.Stay.LateCheckOutFee)
+	cfg.Folio.TaxRate = floatFromEnv("TAX_RATE", cfg.Folio.TaxRate)
+	cfg.Payment.FreeCancellation = durationFromEnv("FREE_CANCELLATION", cfg.Payment.FreeCancellation)
+	cfg.Payment.LateCancellationFee = floatFromEnv("LATE_CANCELLATION_FEE_NIGHTS", cfg.Payment.LateCancellationFee)
+	cfg.Deposit.Rules = depositRulesFromEnv("DEPOSIT_RULES")
+	cfg.Deposit.AutoCancel = os.Getenv("DEPOSIT_AUTO_CANCEL") == "true"
+	cfg.Assignment = envOrDefault("ROOM_ASSIGNMENT", cfg.Assignment)
+	switch cfg.Assignment {
+	case bookingapp.AssignManual, bookingapp.AssignFirstAvailable, bookingapp.AssignBestFit:
+	default:
+		log.Fatalf("invalid ROOM_ASSIGNMENT %q", cfg.Assignment)
+	}
+	return cfg
+}
+
+// runEvery calls fn on every tick until ctx is done, the first time one
+// interval after startup. A non-positive interval disables the job.
+func runEvery(ctx context.Context, interval time.Duration, fn func()) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			fn()
+		}
+	}
+}
+
+// runDaily calls fn every day at the time of day at, an offset from
+// midnight in loc, until ctx is done.
+func runDaily(ctx context.Context, at time.Duration, loc *time.Location, fn func()) {
+	for {
+		now := time.Now().In(loc)
+		next := propertydomain.DateIn(now, loc).At(at, loc)
+		if !next.After(now) {
+			next = propertydomain.DateIn(now, loc).AddDays(1).At(at, loc)
+		}
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			fn()
+		}
+	}
+}
+
 func withCORS(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Reflect origin to support credentials and avoid wildcard with cookies
@@ -0,0 +1,30 @@
+package http
+
+import (
+	nethttp "net/http"
+	"strings"
+
+	"github.com/yourorg/hotel-api/internal/auth/app"
+)
+
+// Authenticate resolves the bearer token of each request to the signed-in
+// user and attaches it to the request context. Requests without a valid
+// token pass through anonymously; handlers decide what they require.
+func Authenticate(svc *app.Service, next nethttp.Handler) nethttp.Handler {
+	return nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		user, err := svc.Authenticate(r.Context(), strings.TrimSpace(token))
+		switch {
+		case err == nil:
+			r = r.WithContext(app.WithUser(r.Context(), *user))
+		case err != app.ErrUnauthenticated:
+			nethttp.Error(w, err.Error(), nethttp.StatusInternalServerError)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,20 @@
+package app
+
+import (
+	"context"
+
+	"github.com/yourorg/hotel-api/internal/auth/domain"
+)
+
+type userKey struct{}
+
+// WithUser returns a copy of ctx carrying the signed-in user.
+func WithUser(ctx context.Context, user domain.User) context.Context {
+	return context.WithValue(ctx, userKey{}, user)
+}
+
+// UserFrom returns the signed-in user carried by ctx, if any.
+func UserFrom(ctx context.Context) (domain.User, bool) {
+	user, ok := ctx.Value(userKey{}).(domain.User)
+	return user, ok
+}
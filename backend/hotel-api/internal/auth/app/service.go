@@ -10,6 +10,7 @@ import (
 
 var (
 	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrUnauthenticated    = errors.New("not signed in")
 )
 
 type PasswordChecker interface {
@@ -21,16 +22,18 @@ type TokenIssuer interface {
 }
 
 type Service struct {
-	users   ports.UserRepository
-	checker PasswordChecker
-	issuer  TokenIssuer
+	users    ports.UserRepository
+	sessions ports.SessionRepository
+	checker  PasswordChecker
+	issuer   TokenIssuer
 }
 
-func NewService(users ports.UserRepository, checker PasswordChecker, issuer TokenIssuer) *Service {
+func NewService(users ports.UserRepository, sessions ports.SessionRepository, checker PasswordChecker, issuer TokenIssuer) *Service {
 	return &Service{
-		users:   users,
-		checker: checker,
-		issuer:  issuer,
+		users:    users,
+		sessions: sessions,
+		checker:  checker,
+		issuer:   issuer,
 	}
 }
 
@@ -61,9 +64,34 @@ func (s *Service) Login(ctx context.Context, req LoginRequest) (*LoginResponse,
 	if err != nil {
 		return nil, err
 	}
+	if err := s.sessions.SaveSession(ctx, token, user.ID); err != nil {
+		return nil, err
+	}
 
 	return &LoginResponse{
 		Token: token,
 		User:  *user,
 	}, nil
 }
+
+// Authenticate returns the user a login token was issued to.
+func (s *Service) Authenticate(ctx context.Context, token string) (*domain.User, error) {
+	if token == "" {
+		return nil, ErrUnauthenticated
+	}
+	userID, err := s.sessions.FindSession(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if userID == "" {
+		return nil, ErrUnauthenticated
+	}
+	user, err := s.users.FindUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUnauthenticated
+	}
+	return user, nil
+}
@@ -2,8 +2,9 @@ package app
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
-	"time"
 
 	"github.com/yourorg/hotel-api/internal/auth/domain"
 )
@@ -27,9 +28,15 @@ func NewStaticTokenIssuer(issuer string) StaticTokenIssuer {
 	return StaticTokenIssuer{issuer: issuer}
 }
 
+// Issue returns a token with a random part, so it cannot be derived from
+// the user's ID.
 func (i StaticTokenIssuer) Issue(ctx context.Context, user domain.User) (string, error) {
 	_ = ctx
-	return fmt.Sprintf("token-%s-%d", user.ID, time.Now().Unix()), nil
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("token-%s-%s", user.ID, hex.EncodeToString(buf)), nil
 }
 
 func HashForSeed(plain string) string {
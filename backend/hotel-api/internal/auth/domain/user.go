@@ -4,6 +4,7 @@ package domain
 type User struct {
 	ID           string
 	Email        string
+	Name         string
 	PasswordHash string
 	Role         string
 }
@@ -8,4 +8,16 @@ import (
 
 type UserRepository interface {
 	FindByEmail(ctx context.Context, email string) (*domain.User, error)
+	FindUserByID(ctx context.Context, id string) (*domain.User, error)
+	// FindUsers returns users whose email or name contains text,
+	// case-insensitively.
+	FindUsers(ctx context.Context, text string) ([]domain.User, error)
+}
+
+// SessionRepository stores the tokens issued at login.
+type SessionRepository interface {
+	SaveSession(ctx context.Context, token, userID string) error
+	// FindSession returns the ID of the user the token was issued to, or ""
+	// for an unknown token.
+	FindSession(ctx context.Context, token string) (string, error)
 }
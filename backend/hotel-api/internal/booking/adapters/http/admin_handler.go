@@ -1,11 +1,20 @@
 package http
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
+	authapp "github.com/yourorg/hotel-api/internal/auth/app"
+	authdomain "github.com/yourorg/hotel-api/internal/auth/domain"
 	bookingapp "github.com/yourorg/hotel-api/internal/booking/app"
+	bookingdomain "github.com/yourorg/hotel-api/internal/booking/domain"
+	bookingports "github.com/yourorg/hotel-api/internal/booking/ports"
+	propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
 )
 
 type AdminHandler struct {
@@ -16,13 +25,124 @@ func NewAdminHandler(svc *bookingapp.Service) *AdminHandler {
 	return &AdminHandler{svc: svc}
 }
 
-type listFilters struct {
-	From *time.Time
-	To   *time.Time
+type staffNoteDTO struct {
+	ID        string `json:"id"`
+	Author    string `json:"author"`
+	Text      string `json:"text"`
+	CreatedAt string `json:"createdAt"`
+}
+
+type createStaffNoteDTO struct {
+	Text string `json:"text"`
+}
+
+type historyEntryDTO struct {
+	ID       string           `json:"id"`
+	Actor    string           `json:"actor"`
+	Action   string           `json:"action"`
+	Reason   string           `json:"reason,omitempty"`
+	Override bool             `json:"override"`
+	At       string           `json:"at"`
+	Changes  []fieldChangeDTO `json:"changes"`
+}
+
+type fieldChangeDTO struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// adminBookingDTO extends the guest view with fields only staff may see.
+type adminBookingDTO struct {
+	bookingDTO
+	StaffNotes []staffNoteDTO `json:"staffNotes,omitempty"`
+}
+
+func toAdminBookingDTO(b bookingdomain.Booking) adminBookingDTO {
+	return adminBookingDTO{
+		bookingDTO: toBookingDTO(b),
+		StaffNotes: toStaffNoteDTOs(b.StaffNotes),
+	}
+}
+
+func toStaffNoteDTO(n bookingdomain.StaffNote) staffNoteDTO {
+	return staffNoteDTO{
+		ID:        n.ID,
+		Author:    n.Author,
+		Text:      n.Text,
+		CreatedAt: n.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func toStaffNoteDTOs(notes []bookingdomain.StaffNote) []staffNoteDTO {
+	var dtos []staffNoteDTO
+	for _, n := range notes {
+		dtos = append(dtos, toStaffNoteDTO(n))
+	}
+	return dtos
 }
 
 func (h *AdminHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	path := strings.Trim(r.URL.Path, "/")
+	parts := strings.Split(path, "/")
+
+	// /api/admin/bookings/{id}/notes[/{noteId}]
+	if len(parts) >= 5 && parts[4] == "notes" {
+		h.handleNotes(w, r, parts[3], parts[5:])
+		return
+	}
+
+	// /api/admin/bookings/{id}/fees/{feeId}/waive
+	if len(parts) == 7 && parts[4] == "fees" && parts[6] == "waive" {
+		h.handleWaiveFee(w, r, parts[3], parts[5])
+		return
+	}
+
+	// /api/admin/bookings/{id}/assign-room
+	if len(parts) == 5 && parts[4] == "assign-room" {
+		h.handleAssignRoom(w, r, parts[3])
+		return
+	}
+
+	// /api/admin/bookings/{id}/move
+	if len(parts) == 5 && parts[4] == "move" {
+		h.handleMoveRoom(w, r, parts[3])
+		return
+	}
+
+	// /api/admin/bookings/{id}/late-check-out
+	if len(parts) == 5 && parts[4] == "late-check-out" {
+		h.handleGrantLateCheckOut(w, r, parts[3])
+		return
+	}
+
+	// /api/admin/bookings/{id}/folio
+	if len(parts) == 5 && parts[4] == "folio" {
+		h.handleFolio(w, r, parts[3])
+		return
+	}
+
+	// /api/admin/bookings/{id}/invoice[/credit-note]
+	if len(parts) == 5 && parts[4] == "invoice" {
+		h.handleInvoice(w, r, parts[3])
+		return
+	}
+	if len(parts) == 6 && parts[4] == "invoice" && parts[5] == "credit-note" {
+		h.handleCreditNote(w, r, parts[3])
+		return
+	}
+
+	// /api/admin/bookings/{id}/invoices
+	if len(parts) == 5 && parts[4] == "invoices" {
+		h.handleInvoices(w, r, parts[3])
+		return
+	}
+
+	// /api/admin/bookings/{id}/history
+	if len(parts) == 5 && parts[4] == "history" {
+		h.handleHistory(w, r, parts[3])
+		return
+	}
 
 	if strings.HasSuffix(path, "/check-in") {
 		h.handleCheckIn(w, r)
@@ -48,25 +168,27 @@ func (h *AdminHandler) handleList(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	bookings, err := h.svc.List(r.Context(), bookingapp.ListFilters{From: filters.From, To: filters.To})
+	result, err := h.svc.Search(r.Context(), filters)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		status := http.StatusInternalServerError
+		switch err {
+		case bookingapp.ErrInvalidSort, bookingapp.ErrInvalidCursor:
+			status = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), status)
 		return
 	}
 
-	var dtos []bookingDTO
-	for _, b := range bookings {
-		dtos = append(dtos, bookingDTO{
-			ID:       b.ID,
-			RoomID:   b.RoomID,
-			UserID:   b.UserID,
-			CheckIn:  b.CheckIn.Format("2006-01-02"),
-			CheckOut: b.CheckOut.Format("2006-01-02"),
-			Status:   b.Status,
-		})
+	var dtos []adminBookingDTO
+	for _, b := range result.Bookings {
+		dtos = append(dtos, toAdminBookingDTO(b))
 	}
 
-	writeJSON(w, map[string]any{"bookings": dtos})
+	writeJSON(w, map[string]any{
+		"bookings":   dtos,
+		"total":      result.Total,
+		"nextCursor": result.NextCursor,
+	})
 }
 
 func (h *AdminHandler) handleCheckIn(w http.ResponseWriter, r *http.Request) {
@@ -80,9 +202,9 @@ func (h *AdminHandler) handleCheckIn(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	actionTime := parseActionDate(r.URL.Query().Get("actionDate"))
+	actionTime := parseActionTime(r)
 
-	booking, err := h.svc.CheckIn(r.Context(), id, actionTime)
+	booking, err := h.svc.CheckIn(r.Context(), id, actionTime, changeMeta(r, adminActor))
 	if err != nil {
 		status := http.StatusInternalServerError
 		switch err {
@@ -90,19 +212,17 @@ func (h *AdminHandler) handleCheckIn(w http.ResponseWriter, r *http.Request) {
 			status = http.StatusNotFound
 		case bookingapp.ErrTooEarlyCheckIn:
 			status = http.StatusBadRequest
+		case bookingapp.ErrEarlyCheckInUnavailable, bookingapp.ErrNoRoomToAssign,
+			bookingapp.ErrNotConfirmed, bookingports.ErrBookingChanged:
+			status = http.StatusConflict
+		case bookingapp.ErrRoomNotAssigned:
+			status = http.StatusBadRequest
 		}
 		http.Error(w, err.Error(), status)
 		return
 	}
 
-	writeJSON(w, bookingDTO{
-		ID:       booking.ID,
-		RoomID:   booking.RoomID,
-		UserID:   booking.UserID,
-		CheckIn:  booking.CheckIn.Format("2006-01-02"),
-		CheckOut: booking.CheckOut.Format("2006-01-02"),
-		Status:   booking.Status,
-	})
+	writeJSON(w, toBookingDTO(*booking))
 }
 
 func (h *AdminHandler) handleCheckOut(w http.ResponseWriter, r *http.Request) {
@@ -116,49 +236,309 @@ func (h *AdminHandler) handleCheckOut(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	actionTime := parseActionDate(r.URL.Query().Get("actionDate"))
+	actionTime := parseActionTime(r)
 
-	booking, err := h.svc.CheckOut(r.Context(), id, actionTime)
+	booking, err := h.svc.CheckOut(r.Context(), id, actionTime, changeMeta(r, adminActor))
+	var owing *bookingapp.BalanceError
+	if errors.As(err, &owing) {
+		// The front desk takes this amount as a payment on the folio, or
+		// overrides with a reason, before checking out again.
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		_ = json.NewEncoder(w).Encode(map[string]any{"code": "outstanding_balance", "message": owing.Error(), "balance": owing.Balance})
+		return
+	}
 	if err != nil {
 		status := http.StatusInternalServerError
 		switch err {
 		case bookingapp.ErrBookingNotFound:
 			status = http.StatusNotFound
-		case bookingapp.ErrTooEarlyCheckOut:
+		case bookingapp.ErrTooEarlyCheckOut, bookingapp.ErrOverrideReasonRequired:
 			status = http.StatusBadRequest
+		case bookingapp.ErrNotCheckedIn, bookingports.ErrBookingChanged:
+			status = http.StatusConflict
 		}
 		http.Error(w, err.Error(), status)
 		return
 	}
 
-	writeJSON(w, bookingDTO{
-		ID:       booking.ID,
-		RoomID:   booking.RoomID,
-		UserID:   booking.UserID,
-		CheckIn:  booking.CheckIn.Format("2006-01-02"),
-		CheckOut: booking.CheckOut.Format("2006-01-02"),
-		Status:   booking.Status,
-	})
+	writeJSON(w, toBookingDTO(*booking))
+}
+
+func (h *AdminHandler) handleNotes(w http.ResponseWriter, r *http.Request, bookingID string, rest []string) {
+	switch {
+	case len(rest) == 0 && r.Method == http.MethodGet:
+		notes, err := h.svc.ListStaffNotes(r.Context(), bookingID)
+		if err != nil {
+			writeNoteError(w, err)
+			return
+		}
+		writeJSON(w, map[string]any{"notes": toStaffNoteDTOs(notes)})
+	case len(rest) == 0 && r.Method == http.MethodPost:
+		staff, ok := staffUser(r)
+		if !ok {
+			http.Error(w, "staff sign-in required", http.StatusUnauthorized)
+			return
+		}
+		var dto createStaffNoteDTO
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			http.Error(w, "invalid body", http.StatusBadRequest)
+			return
+		}
+		note, err := h.svc.AddStaffNote(r.Context(), bookingID, staffName(staff), dto.Text)
+		if err != nil {
+			writeNoteError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		writeJSON(w, toStaffNoteDTO(*note))
+	case len(rest) == 1 && r.Method == http.MethodDelete:
+		if err := h.svc.DeleteStaffNote(r.Context(), bookingID, rest[0], changeMeta(r, adminActor)); err != nil {
+			writeNoteError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case len(rest) <= 1:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (h *AdminHandler) handleHistory(w http.ResponseWriter, r *http.Request, bookingID string) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	entries, err := h.svc.History(r.Context(), bookingID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err == bookingapp.ErrBookingNotFound {
+			status = http.StatusNotFound
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	dtos := make([]historyEntryDTO, 0, len(entries))
+	for _, e := range entries {
+		changes := make([]fieldChangeDTO, 0, len(e.Changes))
+		for _, c := range e.Changes {
+			changes = append(changes, fieldChangeDTO{Field: c.Field, Before: c.Before, After: c.After})
+		}
+		dtos = append(dtos, historyEntryDTO{
+			ID:       e.ID,
+			Actor:    e.Actor,
+			Action:   e.Action,
+			Reason:   e.Reason,
+			Override: e.Override,
+			At:       e.At.Format(time.RFC3339),
+			Changes:  changes,
+		})
+	}
+
+	writeJSON(w, map[string]any{"history": dtos})
+}
+
+type assignRoomDTO struct {
+	RoomID string `json:"roomId"`
+}
+
+func (h *AdminHandler) handleAssignRoom(w http.ResponseWriter, r *http.Request, bookingID string) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var dto assignRoomDTO
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			http.Error(w, "invalid body", http.StatusBadRequest)
+			return
+		}
+	}
+
+	booking, err := h.svc.AssignRoom(r.Context(), bookingID, dto.RoomID, changeMeta(r, adminActor))
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch err {
+		case bookingapp.ErrBookingNotFound, bookingapp.ErrRoomNotFound:
+			status = http.StatusNotFound
+		case bookingapp.ErrRoomUnavailable, bookingapp.ErrNoRoomToAssign:
+			status = http.StatusConflict
+		case bookingapp.ErrRoomNotAssignable, bookingapp.ErrBookingNotAssignable:
+			status = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	writeJSON(w, toAdminBookingDTO(*booking))
+}
+
+type moveRoomDTO struct {
+	RoomID string `json:"roomId"`
+	From   string `json:"from"`
+}
+
+func (h *AdminHandler) handleMoveRoom(w http.ResponseWriter, r *http.Request, bookingID string) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var dto moveRoomDTO
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		http.Error(w, "invalid body", http.StatusBadRequest)
+		return
+	}
+	from, err := propertydomain.ParseDate(dto.From)
+	if err != nil {
+		http.Error(w, "invalid from", http.StatusBadRequest)
+		return
+	}
+
+	booking, err := h.svc.MoveRoom(r.Context(), bookingID, dto.RoomID, from, changeMeta(r, adminActor))
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch err {
+		case bookingapp.ErrBookingNotFound, bookingapp.ErrRoomNotFound:
+			status = http.StatusNotFound
+		case bookingapp.ErrRoomUnavailable:
+			status = http.StatusConflict
+		case bookingapp.ErrRoomNotAssignable, bookingapp.ErrBookingNotAssignable, bookingapp.ErrRoomNotAssigned,
+			bookingapp.ErrInvalidMoveDate:
+			status = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	writeJSON(w, toAdminBookingDTO(*booking))
 }
 
-func parseFilters(r *http.Request) (listFilters, error) {
-	fromStr := r.URL.Query().Get("from")
-	toStr := r.URL.Query().Get("to")
+func (h *AdminHandler) handleGrantLateCheckOut(w http.ResponseWriter, r *http.Request, bookingID string) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	booking, err := h.svc.GrantLateCheckOut(r.Context(), bookingID, changeMeta(r, adminActor))
+	if err != nil {
+		writeFeeError(w, err)
+		return
+	}
+	writeJSON(w, toAdminBookingDTO(*booking))
+}
+
+type waiveFeeDTO struct {
+	Reason string `json:"reason"`
+}
+
+func (h *AdminHandler) handleWaiveFee(w http.ResponseWriter, r *http.Request, bookingID, feeID string) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	meta := changeMeta(r, adminActor)
+	var dto waiveFeeDTO
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			http.Error(w, "invalid body", http.StatusBadRequest)
+			return
+		}
+	}
+	if dto.Reason != "" {
+		meta.Reason = dto.Reason
+	}
+
+	booking, err := h.svc.WaiveFee(r.Context(), bookingID, feeID, meta)
+	if err != nil {
+		writeFeeError(w, err)
+		return
+	}
+	writeJSON(w, toAdminBookingDTO(*booking))
+}
+
+// staffUser returns the signed-in staff member making the request.
+func staffUser(r *http.Request) (authdomain.User, bool) {
+	user, ok := authapp.UserFrom(r.Context())
+	return user, ok && user.Role == "admin"
+}
+
+// staffName is how a staff member is shown on notes and in the history.
+func staffName(u authdomain.User) string {
+	if u.Name != "" {
+		return u.Name
+	}
+	return u.Email
+}
+
+func writeFeeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch err {
+	case bookingapp.ErrBookingNotFound, bookingapp.ErrFeeNotFound:
+		status = http.StatusNotFound
+	case bookingapp.ErrLateCheckOutUnavailable, bookingapp.ErrLateCheckOutAlreadyGranted:
+		status = http.StatusConflict
+	case bookingapp.ErrLateCheckOutNotInHouse, bookingapp.ErrFeeAlreadyWaived, bookingapp.ErrWaiveReasonRequired:
+		status = http.StatusBadRequest
+	}
+	http.Error(w, err.Error(), status)
+}
 
-	var filters listFilters
-	if fromStr != "" {
-		from, err := time.Parse("2006-01-02", fromStr)
+func writeNoteError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch err {
+	case bookingapp.ErrBookingNotFound, bookingapp.ErrStaffNoteNotFound:
+		status = http.StatusNotFound
+	case bookingapp.ErrStaffNoteRequired, bookingapp.ErrStaffNoteTooLong, bookingapp.ErrStaffNoteAuthorRequired:
+		status = http.StatusBadRequest
+	}
+	http.Error(w, err.Error(), status)
+}
+
+func parseFilters(r *http.Request) (bookingapp.SearchFilters, error) {
+	q := r.URL.Query()
+	filters := bookingapp.SearchFilters{
+		RoomID:   q.Get("roomId"),
+		RoomType: q.Get("roomType"),
+		Guest:    q.Get("guest"),
+		Code:     q.Get("code"),
+		Text:     q.Get("q"),
+		Sort:     q.Get("sort"),
+		Cursor:   q.Get("cursor"),
+	}
+	if status := q.Get("status"); status != "" {
+		filters.Statuses = strings.Split(status, ",")
+	}
+	if limit := q.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
 		if err != nil {
-			return filters, err
+			return filters, errors.New("invalid limit")
 		}
-		filters.From = &from
+		filters.Limit = n
 	}
-	if toStr != "" {
-		to, err := time.Parse("2006-01-02", toStr)
+
+	dates := []struct {
+		param  string
+		target **propertydomain.Date
+	}{
+		{"from", &filters.From},
+		{"to", &filters.To},
+		{"arrival", &filters.ArrivalOn},
+		{"departure", &filters.DepartureOn},
+	}
+	for _, d := range dates {
+		value := q.Get(d.param)
+		if value == "" {
+			continue
+		}
+		date, err := propertydomain.ParseDate(value)
 		if err != nil {
-			return filters, err
+			return filters, fmt.Errorf("invalid %s", d.param)
 		}
-		filters.To = &to
+		*d.target = &date
 	}
 	return filters, nil
 }
@@ -171,12 +551,22 @@ func parseBookingID(path string) string {
 	return parts[len(parts)-2]
 }
 
-func parseActionDate(value string) time.Time {
-	if value == "" {
-		return time.Time{}
+// parseActionTime reads a staff override of when an action happens, either
+// as a full "actionTime" timestamp or a date-only "actionDate". A timestamp
+// without a zone is hotel-local time.
+func parseActionTime(r *http.Request) bookingapp.ActionTime {
+	if value := r.URL.Query().Get("actionTime"); value != "" {
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			return bookingapp.ActionTime{At: t}
+		}
+		if t, err := time.Parse("2006-01-02T15:04", value); err == nil {
+			return bookingapp.ActionTime{At: t, Floating: true}
+		}
 	}
-	if t, err := time.Parse("2006-01-02", value); err == nil {
-		return t
+	if value := r.URL.Query().Get("actionDate"); value != "" {
+		if t, err := time.Parse("2006-01-02", value); err == nil {
+			return bookingapp.ActionTime{At: t, DateOnly: true}
+		}
 	}
-	return time.Time{}
+	return bookingapp.ActionTime{}
 }
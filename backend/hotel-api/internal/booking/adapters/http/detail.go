@@ -0,0 +1,113 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	bookingapp "github.com/yourorg/hotel-api/internal/booking/app"
+)
+
+type bookingDetailDTO struct {
+	bookingDTO
+	Room                 *detailRoomDTO `json:"room,omitempty"`
+	Capacity             int            `json:"capacity"`
+	Price                priceDTO       `json:"price"`
+	CancellationDeadline string         `json:"cancellationDeadline"`
+	CancellationFee      float64        `json:"cancellationFee"`
+	Actions              actionsDTO     `json:"actions"`
+}
+
+type detailRoomDTO struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Capacity int    `json:"capacity"`
+}
+
+type priceDTO struct {
+	Nights   []nightPriceDTO `json:"nights"`
+	Room     float64         `json:"room"`
+	Discount float64         `json:"discount"`
+	Extras   float64         `json:"extras"`
+	Tax      float64         `json:"tax"`
+	Total    float64         `json:"total"`
+	Paid     float64         `json:"paid"`
+	Balance  float64         `json:"balance"`
+}
+
+type nightPriceDTO struct {
+	Date   string  `json:"date"`
+	RoomID string  `json:"roomId,omitempty"`
+	Rate   float64 `json:"rate"`
+}
+
+type actionsDTO struct {
+	CanCancel bool `json:"canCancel"`
+	CanModify bool `json:"canModify"`
+	CanPay    bool `json:"canPay"`
+}
+
+func toBookingDetailDTO(d *bookingapp.BookingDetail) bookingDetailDTO {
+	dto := bookingDetailDTO{
+		bookingDTO:           toBookingDTO(d.Booking),
+		Capacity:             d.Capacity,
+		CancellationDeadline: d.CancellationDeadline.Format(time.RFC3339),
+		CancellationFee:      d.CancellationFee,
+		Actions: actionsDTO{
+			CanCancel: d.CanCancel,
+			CanModify: d.CanModify,
+			CanPay:    d.CanPay,
+		},
+		Price: priceDTO{
+			Nights:   []nightPriceDTO{},
+			Room:     d.Price.Room,
+			Discount: d.Price.Discount,
+			Extras:   d.Price.Extras,
+			Tax:      d.Price.Tax,
+			Total:    d.Price.Total,
+			Paid:     d.Price.Paid,
+			Balance:  d.Price.Balance,
+		},
+	}
+	if d.Room != nil {
+		dto.Room = &detailRoomDTO{ID: d.Room.ID, Name: d.Room.Name, Type: d.Room.Type, Capacity: d.Room.Capacity}
+	}
+	for _, n := range d.Price.Nights {
+		dto.Price.Nights = append(dto.Price.Nights, nightPriceDTO{
+			Date:   n.Date.String(),
+			RoomID: n.RoomID,
+			Rate:   n.Rate,
+		})
+	}
+	return dto
+}
+
+// handleDetail serves GET /api/guest/bookings/{id} to the signed-in guest
+// who owns the booking.
+func (h *Handler) handleDetail(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) != 4 || parts[3] == "" {
+		http.Error(w, "invalid booking id", http.StatusBadRequest)
+		return
+	}
+	userID, ok := signedInUser(w, r)
+	if !ok {
+		return
+	}
+
+	detail, err := h.svc.Detail(r.Context(), parts[3], userID)
+	if err == bookingapp.ErrNotBookingOwner {
+		// Do not reveal that the booking exists.
+		err = bookingapp.ErrBookingNotFound
+	}
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err == bookingapp.ErrBookingNotFound {
+			status = http.StatusNotFound
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	writeJSON(w, toBookingDetailDTO(detail))
+}
@@ -0,0 +1,108 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	bookingapp "github.com/yourorg/hotel-api/internal/booking/app"
+	bookingdomain "github.com/yourorg/hotel-api/internal/booking/domain"
+)
+
+type folioLineDTO struct {
+	ID          string  `json:"id"`
+	Kind        string  `json:"kind"`
+	Description string  `json:"description"`
+	Amount      float64 `json:"amount"`
+	Night       string  `json:"night,omitempty"`
+	PostedBy    string  `json:"postedBy"`
+	PostedAt    string  `json:"postedAt"`
+}
+
+type folioDTO struct {
+	BookingID string         `json:"bookingId"`
+	Lines     []folioLineDTO `json:"lines"`
+	Paid      float64        `json:"paid"`
+	Balance   float64        `json:"balance"`
+}
+
+type folioPostingDTO struct {
+	Kind        string  `json:"kind"`
+	Description string  `json:"description"`
+	Amount      float64 `json:"amount"`
+}
+
+func toFolioDTO(b bookingdomain.Booking) folioDTO {
+	lines := b.FolioLines()
+	dto := folioDTO{
+		BookingID: b.ID,
+		Lines:     make([]folioLineDTO, 0, len(lines)),
+		Paid:      b.Paid(),
+		Balance:   b.Balance(),
+	}
+	for _, l := range lines {
+		line := folioLineDTO{
+			ID:          l.ID,
+			Kind:        l.Kind,
+			Description: l.Description,
+			Amount:      l.Amount,
+			PostedBy:    l.PostedBy,
+			PostedAt:    l.PostedAt.Format(time.RFC3339),
+		}
+		if !l.Night.IsZero() {
+			line.Night = l.Night.String()
+		}
+		dto.Lines = append(dto.Lines, line)
+	}
+	return dto
+}
+
+// handleFolio serves GET and POST /api/admin/bookings/{id}/folio.
+func (h *AdminHandler) handleFolio(w http.ResponseWriter, r *http.Request, bookingID string) {
+	switch r.Method {
+	case http.MethodGet:
+		booking, err := h.svc.Folio(r.Context(), bookingID)
+		if err != nil {
+			writeFolioError(w, err)
+			return
+		}
+		writeJSON(w, toFolioDTO(*booking))
+	case http.MethodPost:
+		var dto folioPostingDTO
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			http.Error(w, "invalid body", http.StatusBadRequest)
+			return
+		}
+		_, err := h.svc.PostToFolio(r.Context(), bookingID, bookingapp.FolioPosting{
+			Kind:        dto.Kind,
+			Description: dto.Description,
+			Amount:      dto.Amount,
+		}, changeMeta(r, adminActor))
+		if err != nil {
+			writeFolioError(w, err)
+			return
+		}
+		booking, err := h.svc.Folio(r.Context(), bookingID)
+		if err != nil {
+			writeFolioError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		writeJSON(w, toFolioDTO(*booking))
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func writeFolioError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch err {
+	case bookingapp.ErrBookingNotFound:
+		status = http.StatusNotFound
+	case bookingapp.ErrInvalidFolioKind, bookingapp.ErrInvalidFolioAmount, bookingapp.ErrDescriptionRequired:
+		status = http.StatusBadRequest
+	case bookingapp.ErrRefundExceedsPayments:
+		status = http.StatusConflict
+	}
+	http.Error(w, err.Error(), status)
+}
@@ -2,11 +2,18 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
+	authapp "github.com/yourorg/hotel-api/internal/auth/app"
 	bookingapp "github.com/yourorg/hotel-api/internal/booking/app"
+	bookingdomain "github.com/yourorg/hotel-api/internal/booking/domain"
+	bookingports "github.com/yourorg/hotel-api/internal/booking/ports"
+	propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
+	roomdomain "github.com/yourorg/hotel-api/internal/room/domain"
 )
 
 type Handler struct {
@@ -18,20 +25,183 @@ func NewHandler(svc *bookingapp.Service) *Handler {
 }
 
 type createRequestDTO struct {
-	UserID   string `json:"userId"`
-	RoomID   string `json:"roomId"`
-	CheckIn  string `json:"checkIn"`
-	CheckOut string `json:"checkOut"`
-	Guests   int    `json:"guests"`
+	UserID          string `json:"userId"`
+	RoomID          string `json:"roomId"`
+	RoomType        string `json:"roomType"`
+	CheckIn         string `json:"checkIn"`
+	CheckOut        string `json:"checkOut"`
+	Guests          int    `json:"guests"`
+	ChildAges       []int  `json:"childAges"`
+	SpecialRequests string `json:"specialRequests"`
+	RatePlanID      string `json:"ratePlanId"`
+	PromoCode       string `json:"promoCode"`
+}
+
+type updateRequestDTO struct {
+	SpecialRequests string `json:"specialRequests"`
 }
 
 type bookingDTO struct {
-	ID       string `json:"id"`
-	RoomID   string `json:"roomId"`
-	UserID   string `json:"userId,omitempty"`
-	CheckIn  string `json:"checkIn"`
-	CheckOut string `json:"checkOut"`
-	Status   string `json:"status"`
+	ID               string           `json:"id"`
+	ConfirmationCode string           `json:"confirmationCode,omitempty"`
+	RoomID           string           `json:"roomId"`
+	RoomType         string           `json:"roomType,omitempty"`
+	Segments         []segmentDTO     `json:"segments,omitempty"`
+	Guests           int              `json:"guests,omitempty"`
+	ChildAges        []int            `json:"childAges,omitempty"`
+	UserID           string           `json:"userId,omitempty"`
+	CheckIn          string           `json:"checkIn"`
+	CheckOut         string           `json:"checkOut"`
+	Status           string           `json:"status"`
+	Stage            string           `json:"stage,omitempty"`
+	NoShowFee        float64          `json:"noShowFee,omitempty"`
+	SpecialRequests  string           `json:"specialRequests,omitempty"`
+	RatePlan         *ratePlanDTO     `json:"ratePlan,omitempty"`
+	Promo            *promoDTO        `json:"promo,omitempty"`
+	Fees             []feeDTO         `json:"fees,omitempty"`
+	Payment          *paymentDTO      `json:"payment,omitempty"`
+	Schedule         []installmentDTO `json:"schedule,omitempty"`
+	DepositOverdue   bool             `json:"depositOverdue,omitempty"`
+	DepartureOverdue bool             `json:"departureOverdue,omitempty"`
+}
+
+type ratePlanDTO struct {
+	ID            string   `json:"id"`
+	Name          string   `json:"name"`
+	NonRefundable bool     `json:"nonRefundable"`
+	Inclusions    []string `json:"inclusions"`
+}
+
+type promoDTO struct {
+	Code     string  `json:"code"`
+	Discount float64 `json:"discount"`
+}
+
+type installmentDTO struct {
+	ID          string  `json:"id"`
+	Description string  `json:"description"`
+	Amount      float64 `json:"amount"`
+	Outstanding float64 `json:"outstanding"`
+	DueAt       string  `json:"dueAt"`
+}
+
+type paymentDTO struct {
+	Status     string  `json:"status"`
+	Authorized float64 `json:"authorized"`
+	Captured   float64 `json:"captured"`
+	Refunded   float64 `json:"refunded"`
+}
+
+type segmentDTO struct {
+	RoomID string `json:"roomId"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+}
+
+type feeDTO struct {
+	ID          string  `json:"id"`
+	Kind        string  `json:"kind"`
+	Amount      float64 `json:"amount"`
+	ChargedAt   string  `json:"chargedAt"`
+	Waived      bool    `json:"waived"`
+	WaiveReason string  `json:"waiveReason,omitempty"`
+}
+
+func toBookingDTO(b bookingdomain.Booking) bookingDTO {
+	return bookingDTO{
+		ID:               b.ID,
+		ConfirmationCode: b.ConfirmationCode,
+		RoomID:           b.RoomID,
+		RoomType:         b.RoomType,
+		Segments:         toSegmentDTOs(b.Segments),
+		Guests:           b.Guests,
+		ChildAges:        b.ChildAges,
+		UserID:           b.UserID,
+		CheckIn:          b.CheckIn.String(),
+		CheckOut:         b.CheckOut.String(),
+		Status:           b.Status,
+		NoShowFee:        b.NoShowFee,
+		SpecialRequests:  b.SpecialRequests,
+		RatePlan:         toRatePlanDTO(b.RatePlan),
+		Promo:            toPromoDTO(b.Promo),
+		Fees:             toFeeDTOs(b.Fees),
+		Payment:          toPaymentDTO(b.Payment),
+		Schedule:         toInstallmentDTOs(b),
+		DepositOverdue:   b.DepositOverdue,
+		DepartureOverdue: b.DepartureOverdue,
+	}
+}
+
+func toInstallmentDTOs(b bookingdomain.Booking) []installmentDTO {
+	var dtos []installmentDTO
+	for _, in := range b.Schedule {
+		dtos = append(dtos, installmentDTO{
+			ID:          in.ID,
+			Description: in.Description,
+			Amount:      in.Amount,
+			Outstanding: b.InstallmentOutstanding(in.ID),
+			DueAt:       in.DueAt.Format(time.RFC3339),
+		})
+	}
+	return dtos
+}
+
+func toRatePlanDTO(p *bookingdomain.BookedPlan) *ratePlanDTO {
+	if p == nil {
+		return nil
+	}
+	return &ratePlanDTO{
+		ID:            p.ID,
+		Name:          p.Name,
+		NonRefundable: p.Cancellation.NonRefundable,
+		Inclusions:    append([]string{}, p.Inclusions...),
+	}
+}
+
+func toPromoDTO(p *bookingdomain.AppliedPromo) *promoDTO {
+	if p == nil {
+		return nil
+	}
+	return &promoDTO{Code: p.Code, Discount: p.Discount}
+}
+
+func toPaymentDTO(p bookingdomain.PaymentState) *paymentDTO {
+	if p.AuthorizationID == "" {
+		return nil
+	}
+	return &paymentDTO{
+		Status:     p.Status,
+		Authorized: p.Authorized,
+		Captured:   p.Captured,
+		Refunded:   p.Refunded,
+	}
+}
+
+func toSegmentDTOs(segments []bookingdomain.Segment) []segmentDTO {
+	var dtos []segmentDTO
+	for _, seg := range segments {
+		dtos = append(dtos, segmentDTO{
+			RoomID: seg.RoomID,
+			From:   seg.From.String(),
+			To:     seg.To.String(),
+		})
+	}
+	return dtos
+}
+
+func toFeeDTOs(fees []bookingdomain.Fee) []feeDTO {
+	var dtos []feeDTO
+	for _, f := range fees {
+		dtos = append(dtos, feeDTO{
+			ID:          f.ID,
+			Kind:        f.Kind,
+			Amount:      f.Amount,
+			ChargedAt:   f.ChargedAt.Format(time.RFC3339),
+			Waived:      f.Waived,
+			WaiveReason: f.WaiveReason,
+		})
+	}
+	return dtos
 }
 
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -39,11 +209,27 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		h.handleCancel(w, r)
 		return
 	}
+	if strings.HasSuffix(r.URL.Path, ".ics") {
+		h.handleICS(w, r)
+		return
+	}
+	if strings.HasSuffix(r.URL.Path, "/pay") {
+		h.handlePay(w, r)
+		return
+	}
 	if r.Method == http.MethodPost {
 		h.handleCreate(w, r)
 		return
 	}
+	if r.Method == http.MethodPatch {
+		h.handleUpdate(w, r)
+		return
+	}
 	if r.Method == http.MethodGet {
+		if len(strings.Split(strings.Trim(r.URL.Path, "/"), "/")) == 4 {
+			h.handleDetail(w, r)
+			return
+		}
 		h.handleList(w, r)
 		return
 	}
@@ -57,69 +243,155 @@ func (h *Handler) handleCreate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	checkIn, err := time.Parse("2006-01-02", req.CheckIn)
+	checkIn, err := propertydomain.ParseDate(req.CheckIn)
 	if err != nil {
 		http.Error(w, "invalid checkIn", http.StatusBadRequest)
 		return
 	}
-	checkOut, err := time.Parse("2006-01-02", req.CheckOut)
+	checkOut, err := propertydomain.ParseDate(req.CheckOut)
 	if err != nil {
 		http.Error(w, "invalid checkOut", http.StatusBadRequest)
 		return
 	}
 
 	resp, err := h.svc.Create(r.Context(), bookingapp.CreateRequest{
-		UserID:   req.UserID,
-		RoomID:   req.RoomID,
-		CheckIn:  checkIn,
-		CheckOut: checkOut,
-		Guests:   req.Guests,
+		UserID:          req.UserID,
+		RoomID:          req.RoomID,
+		RoomType:        req.RoomType,
+		CheckIn:         checkIn,
+		CheckOut:        checkOut,
+		Guests:          req.Guests,
+		ChildAges:       req.ChildAges,
+		SpecialRequests: req.SpecialRequests,
+		RatePlanID:      req.RatePlanID,
+		Channel:         roomdomain.ChannelWeb,
+		PromoCode:       req.PromoCode,
 	})
+	var violation *roomdomain.RestrictionError
+	if errors.As(err, &violation) {
+		writeViolation(w, violation)
+		return
+	}
+	var promoErr *roomdomain.PromoError
+	if errors.As(err, &promoErr) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_ = json.NewEncoder(w).Encode(map[string]string{"code": promoErr.Code, "message": promoErr.Error()})
+		return
+	}
 	if err != nil {
 		status := http.StatusInternalServerError
 		switch err {
-		case bookingapp.ErrInvalidDateRange, bookingapp.ErrRoomUnavailable, bookingapp.ErrGuestsExceedRoom, bookingapp.ErrRoomNotFound:
+		case bookingapp.ErrInvalidDateRange, bookingapp.ErrRoomUnavailable, bookingapp.ErrGuestsExceedRoom, bookingapp.ErrRoomNotFound,
+			bookingapp.ErrSpecialRequestsTooLong, bookingapp.ErrRoomRequired, bookingapp.ErrRoomTypeNotFound,
+			bookingapp.ErrRatePlanNotFound, bookingapp.ErrRatePlanNotOffered, bookingapp.ErrPromoNotFound,
+			roomdomain.ErrInvalidParty:
 			status = http.StatusBadRequest
+		case bookingapp.ErrPaymentDeclined:
+			status = http.StatusPaymentRequired
 		}
 		http.Error(w, err.Error(), status)
 		return
 	}
 
 	writeJSON(w, bookingDTO{
-		ID:       resp.ID,
-		RoomID:   resp.RoomID,
-		CheckIn:  resp.CheckIn.Format("2006-01-02"),
-		CheckOut: resp.CheckOut.Format("2006-01-02"),
-		Status:   resp.Status,
+		ID:               resp.ID,
+		ConfirmationCode: resp.ConfirmationCode,
+		RoomID:           resp.RoomID,
+		RoomType:         resp.RoomType,
+		Guests:           resp.Guests,
+		ChildAges:        resp.ChildAges,
+		CheckIn:          resp.CheckIn.String(),
+		CheckOut:         resp.CheckOut.String(),
+		Status:           resp.Status,
+		SpecialRequests:  resp.SpecialRequests,
+		RatePlan:         toRatePlanDTO(resp.RatePlan),
+		Promo:            toPromoDTO(resp.Promo),
+		Payment:          toPaymentDTO(resp.Payment),
+		Schedule:         toInstallmentDTOs(bookingdomain.Booking{Schedule: resp.Schedule, Folio: resp.Folio}),
 	})
 }
 
+// handleList serves GET /api/guest/bookings?userId=...&status=... with
+// status one of upcoming, current, past or cancelled, paged by cursor.
 func (h *Handler) handleList(w http.ResponseWriter, r *http.Request) {
-	userID := r.URL.Query().Get("userId")
-	if userID == "" {
+	q := r.URL.Query()
+	query := bookingapp.GuestBookingsQuery{
+		UserID: q.Get("userId"),
+		Stage:  q.Get("status"),
+		Cursor: q.Get("cursor"),
+	}
+	if query.UserID == "" {
 		http.Error(w, "userId required", http.StatusBadRequest)
 		return
 	}
+	if limit := q.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+		query.Limit = n
+	}
 
-	bookings, err := h.svc.ListByUser(r.Context(), userID)
+	result, err := h.svc.ListByUser(r.Context(), query)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		status := http.StatusInternalServerError
+		switch err {
+		case bookingapp.ErrInvalidStage, bookingapp.ErrInvalidCursor:
+			status = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), status)
 		return
 	}
 
-	var dtos []bookingDTO
-	for _, b := range bookings {
-		dtos = append(dtos, bookingDTO{
-			ID:       b.ID,
-			RoomID:   b.RoomID,
-			UserID:   b.UserID,
-			CheckIn:  b.CheckIn.Format("2006-01-02"),
-			CheckOut: b.CheckOut.Format("2006-01-02"),
-			Status:   b.Status,
-		})
+	dtos := make([]bookingDTO, 0, len(result.Bookings))
+	for _, b := range result.Bookings {
+		dto := toBookingDTO(b.Booking)
+		dto.Stage = b.Stage
+		dtos = append(dtos, dto)
 	}
 
-	writeJSON(w, map[string]any{"bookings": dtos})
+	writeJSON(w, map[string]any{
+		"bookings":   dtos,
+		"total":      result.Total,
+		"nextCursor": result.NextCursor,
+	})
+}
+
+func (h *Handler) handleUpdate(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) != 4 || parts[3] == "" {
+		http.Error(w, "invalid booking id", http.StatusBadRequest)
+		return
+	}
+	userID, ok := signedInUser(w, r)
+	if !ok {
+		return
+	}
+
+	var req updateRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid body", http.StatusBadRequest)
+		return
+	}
+
+	booking, err := h.svc.UpdateSpecialRequests(r.Context(), parts[3], userID, req.SpecialRequests)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch err {
+		case bookingapp.ErrBookingNotFound:
+			status = http.StatusNotFound
+		case bookingapp.ErrNotBookingOwner:
+			status = http.StatusForbidden
+		case bookingapp.ErrBookingNotModifiable, bookingapp.ErrSpecialRequestsTooLong:
+			status = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	writeJSON(w, toBookingDTO(*booking))
 }
 
 func (h *Handler) handleCancel(w http.ResponseWriter, r *http.Request) {
@@ -138,13 +410,15 @@ func (h *Handler) handleCancel(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.svc.Cancel(r.Context(), id); err != nil {
+	if err := h.svc.Cancel(r.Context(), id, changeMeta(r, guestActor)); err != nil {
 		status := http.StatusInternalServerError
 		switch err {
 		case bookingapp.ErrBookingNotFound:
 			status = http.StatusNotFound
 		case bookingapp.ErrCannotCancelPast:
 			status = http.StatusBadRequest
+		case bookingapp.ErrNotConfirmed, bookingports.ErrBookingChanged:
+			status = http.StatusConflict
 		}
 		http.Error(w, err.Error(), status)
 		return
@@ -153,7 +427,84 @@ func (h *Handler) handleCancel(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+const (
+	adminActor = "admin"
+	guestActor = "guest"
+)
+
+// handlePay charges the signed-in guest for the next scheduled payment on
+// POST /api/guest/bookings/{id}/pay.
+func (h *Handler) handlePay(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	id := parseBookingID(r.URL.Path)
+	if id == "" {
+		http.Error(w, "invalid booking id", http.StatusBadRequest)
+		return
+	}
+	userID, ok := signedInUser(w, r)
+	if !ok {
+		return
+	}
+
+	booking, err := h.svc.PayInstallment(r.Context(), id, userID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch err {
+		case bookingapp.ErrBookingNotFound:
+			status = http.StatusNotFound
+		case bookingapp.ErrNotBookingOwner:
+			status = http.StatusForbidden
+		case bookingapp.ErrBookingNotModifiable, bookingapp.ErrNothingDue:
+			status = http.StatusConflict
+		case bookingapp.ErrPaymentDeclined:
+			status = http.StatusPaymentRequired
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	writeJSON(w, toBookingDTO(*booking))
+}
+
+// signedInUser returns the ID of the user signed in on the request, or
+// answers 401 when there is none.
+func signedInUser(w http.ResponseWriter, r *http.Request) (string, bool) {
+	user, ok := authapp.UserFrom(r.Context())
+	if !ok {
+		http.Error(w, "sign-in required", http.StatusUnauthorized)
+		return "", false
+	}
+	return user.ID, true
+}
+
+// changeMeta takes the acting user from the signed-in session, or
+// fallbackActor for anonymous requests, and the reason and "override" flag
+// from the query string. Staff are recorded by name, guests by user ID.
+func changeMeta(r *http.Request, fallbackActor string) bookingapp.ChangeMeta {
+	actor := fallbackActor
+	if staff, ok := staffUser(r); ok {
+		actor = staffName(staff)
+	} else if user, ok := authapp.UserFrom(r.Context()); ok {
+		actor = user.ID
+	}
+	return bookingapp.ChangeMeta{
+		Actor:    actor,
+		Reason:   r.URL.Query().Get("reason"),
+		Override: r.URL.Query().Get("override") == "true",
+	}
+}
+
 func writeJSON(w http.ResponseWriter, payload any) {
 	w.Header().Set("Content-Type", "application/json")
 	_ = json.NewEncoder(w).Encode(payload)
 }
+
+// writeViolation reports a broken booking rule with a code the guest UI can
+// explain.
+func writeViolation(w http.ResponseWriter, v *roomdomain.RestrictionError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	_ = json.NewEncoder(w).Encode(map[string]string{"code": v.Code, "message": v.Error()})
+}
@@ -0,0 +1,167 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	bookingapp "github.com/yourorg/hotel-api/internal/booking/app"
+)
+
+const icalUTCTime = "20060102T150405Z"
+
+// CalendarHandler serves guests' subscribable booking feeds. GET
+// /api/guest/calendar returns the signed-in guest's feed URL, POST replaces
+// it with a new one, and GET /api/guest/calendar/{token}.ics serves the feed
+// itself.
+type CalendarHandler struct {
+	svc *bookingapp.Service
+}
+
+func NewCalendarHandler(svc *bookingapp.Service) *CalendarHandler {
+	return &CalendarHandler{svc: svc}
+}
+
+func (h *CalendarHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) == 3 {
+		h.handleFeedURL(w, r)
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if len(parts) != 4 || !strings.HasSuffix(parts[3], ".ics") {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	events, err := h.svc.CalendarFeed(r.Context(), strings.TrimSuffix(parts[3], ".ics"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err == bookingapp.ErrInvalidCalendarToken {
+			status = http.StatusNotFound
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	writeCalendar(w, events)
+}
+
+// handleFeedURL returns the signed-in guest's feed URL, issuing a new token
+// on POST so a leaked URL can be revoked.
+func (h *CalendarHandler) handleFeedURL(w http.ResponseWriter, r *http.Request) {
+	userID, ok := signedInUser(w, r)
+	if !ok {
+		return
+	}
+
+	var token string
+	var err error
+	switch r.Method {
+	case http.MethodGet:
+		token, err = h.svc.CalendarToken(r.Context(), userID)
+	case http.MethodPost:
+		token, err = h.svc.RotateCalendarToken(r.Context(), userID)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, map[string]string{"url": "/api/guest/calendar/" + token + ".ics"})
+}
+
+// handleICS serves GET /api/guest/bookings/{id}.ics to the signed-in guest
+// who owns the booking, as a single event.
+func (h *Handler) handleICS(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	userID, ok := signedInUser(w, r)
+	if !ok {
+		return
+	}
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	id := strings.TrimSuffix(parts[len(parts)-1], ".ics")
+
+	event, err := h.svc.BookingEvent(r.Context(), id, userID)
+	if err == bookingapp.ErrNotBookingOwner {
+		// Do not reveal that the booking exists.
+		err = bookingapp.ErrBookingNotFound
+	}
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err == bookingapp.ErrBookingNotFound {
+			status = http.StatusNotFound
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	w.Header().Set("Content-Disposition", `attachment; filename="`+id+`.ics"`)
+	writeCalendar(w, []bookingapp.CalendarEvent{*event})
+}
+
+// writeCalendar writes the events as an RFC 5545 calendar.
+func writeCalendar(w http.ResponseWriter, events []bookingapp.CalendarEvent) {
+	var b strings.Builder
+	line := func(content string) {
+		b.WriteString(foldICalLine(content))
+		b.WriteString("\r\n")
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//hotel-api//bookings//EN")
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	for _, e := range events {
+		status := "CONFIRMED"
+		if e.Cancelled {
+			status = "CANCELLED"
+		}
+		line("BEGIN:VEVENT")
+		line("UID:" + escapeICalText(e.UID))
+		line("DTSTAMP:" + e.Stamp.UTC().Format(icalUTCTime))
+		line("DTSTART:" + e.Start.UTC().Format(icalUTCTime))
+		line("DTEND:" + e.End.UTC().Format(icalUTCTime))
+		line("SEQUENCE:" + strconv.Itoa(e.Sequence))
+		line("STATUS:" + status)
+		line("SUMMARY:" + escapeICalText(e.Summary))
+		if e.Location != "" {
+			line("LOCATION:" + escapeICalText(e.Location))
+		}
+		line("DESCRIPTION:" + escapeICalText(e.Details))
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	_, _ = w.Write([]byte(b.String()))
+}
+
+func escapeICalText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(s)
+}
+
+// foldICalLine splits content lines longer than 75 octets, continuing them
+// on lines that start with a space, without breaking UTF-8 sequences.
+func foldICalLine(s string) string {
+	const limit = 75
+	var b strings.Builder
+	n := 0
+	for _, r := range s {
+		size := len(string(r))
+		if n+size > limit {
+			b.WriteString("\r\n ")
+			n = 1
+		}
+		b.WriteRune(r)
+		n += size
+	}
+	return b.String()
+}
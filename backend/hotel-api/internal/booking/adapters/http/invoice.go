@@ -0,0 +1,239 @@
+package http
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	bookingapp "github.com/yourorg/hotel-api/internal/booking/app"
+	bookingdomain "github.com/yourorg/hotel-api/internal/booking/domain"
+)
+
+type invoiceDTO struct {
+	ID        string             `json:"id"`
+	Number    string             `json:"number"`
+	Kind      string             `json:"kind"`
+	BookingID string             `json:"bookingId"`
+	Corrects  string             `json:"corrects,omitempty"`
+	Reason    string             `json:"reason,omitempty"`
+	IssuedAt  string             `json:"issuedAt"`
+	IssuedBy  string             `json:"issuedBy"`
+	Property  invoicePropertyDTO `json:"property"`
+	BillTo    billingDetailsDTO  `json:"billTo"`
+	StayFrom  string             `json:"stayFrom"`
+	StayTo    string             `json:"stayTo"`
+	Lines     []invoiceLineDTO   `json:"lines"`
+	Taxes     []taxBreakdownDTO  `json:"taxes"`
+	Subtotal  float64            `json:"subtotal"`
+	TaxTotal  float64            `json:"taxTotal"`
+	Total     float64            `json:"total"`
+	Paid      float64            `json:"paid"`
+}
+
+type invoicePropertyDTO struct {
+	Name    string `json:"name"`
+	Address string `json:"address,omitempty"`
+	Phone   string `json:"phone,omitempty"`
+	Email   string `json:"email,omitempty"`
+	TaxID   string `json:"taxId,omitempty"`
+}
+
+type billingDetailsDTO struct {
+	Name  string `json:"name"`
+	Email string `json:"email,omitempty"`
+}
+
+type invoiceLineDTO struct {
+	Description string  `json:"description"`
+	Date        string  `json:"date"`
+	Quantity    int     `json:"quantity"`
+	UnitPrice   float64 `json:"unitPrice"`
+	Amount      float64 `json:"amount"`
+	TaxRate     float64 `json:"taxRate"`
+	Tax         float64 `json:"tax"`
+}
+
+type taxBreakdownDTO struct {
+	Rate    float64 `json:"rate"`
+	Taxable float64 `json:"taxable"`
+	Tax     float64 `json:"tax"`
+}
+
+type creditNoteRequestDTO struct {
+	Reason string `json:"reason"`
+}
+
+func toInvoiceDTO(inv bookingdomain.Invoice) invoiceDTO {
+	dto := invoiceDTO{
+		ID:        inv.ID,
+		Number:    inv.Number,
+		Kind:      inv.Kind,
+		BookingID: inv.BookingID,
+		Corrects:  inv.Corrects,
+		Reason:    inv.Reason,
+		IssuedAt:  inv.IssuedAt.Format(time.RFC3339),
+		IssuedBy:  inv.IssuedBy,
+		Property: invoicePropertyDTO{
+			Name:    inv.Property.Name,
+			Address: inv.Property.Address,
+			Phone:   inv.Property.Phone,
+			Email:   inv.Property.Email,
+			TaxID:   inv.Property.TaxID,
+		},
+		BillTo:   billingDetailsDTO{Name: inv.BillTo.Name, Email: inv.BillTo.Email},
+		StayFrom: inv.StayFrom.String(),
+		StayTo:   inv.StayTo.String(),
+		Lines:    make([]invoiceLineDTO, 0, len(inv.Lines)),
+		Taxes:    make([]taxBreakdownDTO, 0, len(inv.Taxes)),
+		Subtotal: inv.Subtotal,
+		TaxTotal: inv.TaxTotal,
+		Total:    inv.Total,
+		Paid:     inv.Paid,
+	}
+	for _, l := range inv.Lines {
+		dto.Lines = append(dto.Lines, invoiceLineDTO{
+			Description: l.Description,
+			Date:        l.Date.String(),
+			Quantity:    l.Quantity,
+			UnitPrice:   l.UnitPrice,
+			Amount:      l.Amount,
+			TaxRate:     l.TaxRate,
+			Tax:         l.Tax,
+		})
+	}
+	for _, t := range inv.Taxes {
+		dto.Taxes = append(dto.Taxes, taxBreakdownDTO{Rate: t.Rate, Taxable: t.Taxable, Tax: t.Tax})
+	}
+	return dto
+}
+
+// handleInvoice serves /api/admin/bookings/{id}/invoice. GET returns the
+// current invoice as JSON, or as a printable page with ?format=html or an
+// HTML Accept header; POST issues it.
+func (h *AdminHandler) handleInvoice(w http.ResponseWriter, r *http.Request, bookingID string) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		inv, err := h.svc.IssueInvoice(r.Context(), bookingID, changeMeta(r, adminActor))
+		if err != nil {
+			writeInvoiceError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		writeJSON(w, toInvoiceDTO(*inv))
+		return
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	inv, err := h.svc.Invoice(r.Context(), bookingID)
+	if err != nil {
+		writeInvoiceError(w, err)
+		return
+	}
+
+	if r.URL.Query().Get("format") == "html" || strings.Contains(r.Header.Get("Accept"), "text/html") {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := invoiceTemplate.Execute(w, toInvoiceDTO(*inv)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	writeJSON(w, toInvoiceDTO(*inv))
+}
+
+func (h *AdminHandler) handleInvoices(w http.ResponseWriter, r *http.Request, bookingID string) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	docs, err := h.svc.ListInvoices(r.Context(), bookingID)
+	if err != nil {
+		writeInvoiceError(w, err)
+		return
+	}
+	dtos := make([]invoiceDTO, 0, len(docs))
+	for _, d := range docs {
+		dtos = append(dtos, toInvoiceDTO(d))
+	}
+	writeJSON(w, dtos)
+}
+
+func (h *AdminHandler) handleCreditNote(w http.ResponseWriter, r *http.Request, bookingID string) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	meta := changeMeta(r, adminActor)
+	var dto creditNoteRequestDTO
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			http.Error(w, "invalid body", http.StatusBadRequest)
+			return
+		}
+	}
+	if dto.Reason != "" {
+		meta.Reason = dto.Reason
+	}
+
+	note, err := h.svc.IssueCreditNote(r.Context(), bookingID, meta)
+	if err != nil {
+		writeInvoiceError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	writeJSON(w, toInvoiceDTO(*note))
+}
+
+func writeInvoiceError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch err {
+	case bookingapp.ErrBookingNotFound, bookingapp.ErrInvoiceNotIssued:
+		status = http.StatusNotFound
+	case bookingapp.ErrInvoiceNotAvailable, bookingapp.ErrNoInvoice:
+		status = http.StatusConflict
+	case bookingapp.ErrCreditNoteReasonMissing:
+		status = http.StatusBadRequest
+	}
+	http.Error(w, err.Error(), status)
+}
+
+var invoiceTemplate = template.Must(template.New("invoice").Funcs(template.FuncMap{
+	"money":   func(v float64) string { return strconv.FormatFloat(v, 'f', 2, 64) },
+	"percent": func(v float64) string { return strconv.FormatFloat(v*100, 'f', -1, 64) + "%" },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{if eq .Kind "credit_note"}}Credit note{{else}}Invoice{{end}} {{.Number}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; width: 100%; margin-top: 1em; }
+th, td { border-bottom: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+td.num, th.num { text-align: right; }
+</style>
+</head>
+<body>
+<h1>{{if eq .Kind "credit_note"}}Credit note{{else}}Invoice{{end}} {{.Number}}</h1>
+<p><strong>{{.Property.Name}}</strong><br>{{.Property.Address}}{{if .Property.TaxID}}<br>Tax ID: {{.Property.TaxID}}{{end}}</p>
+<p>Bill to: {{.BillTo.Name}}{{if .BillTo.Email}} &lt;{{.BillTo.Email}}&gt;{{end}}<br>
+Issued: {{.IssuedAt}}<br>
+Stay: {{.StayFrom}} to {{.StayTo}}{{if .Corrects}}<br>Corrects: {{.Corrects}} ({{.Reason}}){{end}}</p>
+<table>
+<tr><th>Date</th><th>Description</th><th class="num">Qty</th><th class="num">Unit price</th><th class="num">Amount</th><th class="num">Tax</th></tr>
+{{range .Lines}}<tr><td>{{.Date}}</td><td>{{.Description}}</td><td class="num">{{.Quantity}}</td><td class="num">{{money .UnitPrice}}</td><td class="num">{{money .Amount}}</td><td class="num">{{money .Tax}}</td></tr>
+{{end}}</table>
+<table>
+<tr><th>Tax rate</th><th class="num">Taxable</th><th class="num">Tax</th></tr>
+{{range .Taxes}}<tr><td>{{percent .Rate}}</td><td class="num">{{money .Taxable}}</td><td class="num">{{money .Tax}}</td></tr>
+{{end}}</table>
+<p>Subtotal: {{money .Subtotal}}<br>Tax: {{money .TaxTotal}}<br><strong>Total: {{money .Total}}</strong>{{if .Paid}}<br>Paid: {{money .Paid}}{{end}}</p>
+</body>
+</html>
+`))
@@ -0,0 +1,107 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	bookingapp "github.com/yourorg/hotel-api/internal/booking/app"
+	propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
+)
+
+// JobsHandler lets admins trigger background booking jobs on demand.
+type JobsHandler struct {
+	svc *bookingapp.Service
+}
+
+func NewJobsHandler(svc *bookingapp.Service) *JobsHandler {
+	return &JobsHandler{svc: svc}
+}
+
+func (h *JobsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.Trim(r.URL.Path, "/")
+
+	if strings.HasSuffix(path, "/no-shows") {
+		h.handleNoShows(w, r)
+		return
+	}
+	if strings.HasSuffix(path, "/deposits") {
+		h.handleDeposits(w, r)
+		return
+	}
+	if strings.HasSuffix(path, "/night-audit") {
+		h.handleNightAudit(w, r)
+		return
+	}
+
+	w.WriteHeader(http.StatusNotFound)
+}
+
+func (h *JobsHandler) handleNoShows(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	processed, err := h.svc.ProcessNoShows(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	dtos := make([]bookingDTO, 0, len(processed))
+	for _, b := range processed {
+		dtos = append(dtos, toBookingDTO(b))
+	}
+
+	writeJSON(w, map[string]any{"processed": len(dtos), "bookings": dtos})
+}
+
+func (h *JobsHandler) handleDeposits(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	processed, err := h.svc.ProcessOverdueDeposits(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	dtos := make([]bookingDTO, 0, len(processed))
+	for _, b := range processed {
+		dtos = append(dtos, toBookingDTO(b))
+	}
+
+	writeJSON(w, map[string]any{"processed": len(dtos), "bookings": dtos})
+}
+
+// handleNightAudit closes the business date, or the past date given as
+// ?date=YYYY-MM-DD, and returns its end-of-day report.
+func (h *JobsHandler) handleNightAudit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	var date propertydomain.Date
+	if value := r.URL.Query().Get("date"); value != "" {
+		d, err := propertydomain.ParseDate(value)
+		if err != nil {
+			http.Error(w, "invalid date", http.StatusBadRequest)
+			return
+		}
+		date = d
+	}
+
+	report, err := h.svc.NightAudit(r.Context(), date, changeMeta(r, adminActor))
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch err {
+		case bookingapp.ErrAuditDayNotOpen, bookingapp.ErrAuditTooEarly:
+			status = http.StatusConflict
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	writeJSON(w, toAuditReportDTO(*report))
+}
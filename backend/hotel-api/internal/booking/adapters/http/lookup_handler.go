@@ -0,0 +1,155 @@
+package http
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	bookingapp "github.com/yourorg/hotel-api/internal/booking/app"
+)
+
+// LookupHandler serves the public booking lookup by confirmation code and
+// guest email. Requests are rate-limited per client address so codes cannot
+// be enumerated. Requests relayed by one of the trusted proxies are keyed on
+// the X-Real-IP address the proxy sets.
+type LookupHandler struct {
+	svc     *bookingapp.Service
+	limiter *rateLimiter
+	proxies []*net.IPNet
+}
+
+func NewLookupHandler(svc *bookingapp.Service, limit int, window time.Duration, trustedProxies []*net.IPNet) *LookupHandler {
+	return &LookupHandler{
+		svc:     svc,
+		limiter: newRateLimiter(limit, window),
+		proxies: trustedProxies,
+	}
+}
+
+type lookupRequestDTO struct {
+	Code  string `json:"code"`
+	Email string `json:"email"`
+}
+
+func (h *LookupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !h.limiter.Allow(clientAddr(r, h.proxies)) {
+		w.Header().Set("Retry-After", strconv.Itoa(int(h.limiter.window.Seconds())))
+		http.Error(w, "too many lookup attempts", http.StatusTooManyRequests)
+		return
+	}
+
+	var req lookupRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid body", http.StatusBadRequest)
+		return
+	}
+
+	booking, err := h.svc.Lookup(r.Context(), req.Code, req.Email)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err == bookingapp.ErrBookingNotFound {
+			status = http.StatusNotFound
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	dto := toBookingDTO(*booking)
+	dto.UserID = ""
+	writeJSON(w, dto)
+}
+
+// rateLimiter is a fixed-window counter keyed by client address. Expired
+// windows are pruned in the background once per window.
+type rateLimiter struct {
+	mu     sync.Mutex
+	limit  int
+	window time.Duration
+	nowFn  func() time.Time
+	hits   map[string]*rateWindow
+}
+
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+func newRateLimiter(limit int, window time.Duration) *rateLimiter {
+	l := &rateLimiter{
+		limit:  limit,
+		window: window,
+		nowFn:  time.Now,
+		hits:   make(map[string]*rateWindow),
+	}
+	if window > 0 {
+		go l.pruneEvery(window)
+	}
+	return l
+}
+
+func (l *rateLimiter) Allow(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.nowFn()
+	win, ok := l.hits[key]
+	if !ok || now.Sub(win.start) >= l.window {
+		win = &rateWindow{start: now}
+		l.hits[key] = win
+	}
+	win.count++
+	return win.count <= l.limit
+}
+
+func (l *rateLimiter) pruneEvery(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		l.prune()
+	}
+}
+
+// prune drops the windows that have expired.
+func (l *rateLimiter) prune() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.nowFn()
+	for k, win := range l.hits {
+		if now.Sub(win.start) >= l.window {
+			delete(l.hits, k)
+		}
+	}
+}
+
+// clientAddr is the address a request came from: the peer, or when the peer
+// is a trusted proxy, the client address it reports in X-Real-IP.
+func clientAddr(r *http.Request, trustedProxies []*net.IPNet) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	peer := net.ParseIP(host)
+	if peer == nil {
+		return host
+	}
+	for _, proxy := range trustedProxies {
+		if !proxy.Contains(peer) {
+			continue
+		}
+		if ip := net.ParseIP(strings.TrimSpace(r.Header.Get("X-Real-IP"))); ip != nil {
+			return ip.String()
+		}
+		break
+	}
+	return host
+}
@@ -0,0 +1,106 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	bookingapp "github.com/yourorg/hotel-api/internal/booking/app"
+	bookingdomain "github.com/yourorg/hotel-api/internal/booking/domain"
+	propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
+)
+
+// OverbookingHandler lets revenue staff manage how far each room type may be
+// sold beyond its physical rooms.
+type OverbookingHandler struct {
+	svc *bookingapp.Service
+}
+
+func NewOverbookingHandler(svc *bookingapp.Service) *OverbookingHandler {
+	return &OverbookingHandler{svc: svc}
+}
+
+type overbookingLimitDTO struct {
+	RoomType string `json:"roomType,omitempty"`
+	Date     string `json:"date,omitempty"`
+	Limit    int    `json:"limit"`
+}
+
+func (h *OverbookingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.handleList(w, r)
+	case http.MethodPut:
+		h.handleSet(w, r)
+	case http.MethodDelete:
+		h.handleDelete(w, r)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *OverbookingHandler) handleList(w http.ResponseWriter, r *http.Request) {
+	limits, err := h.svc.OverbookingLimits(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	dtos := make([]overbookingLimitDTO, 0, len(limits))
+	for _, l := range limits {
+		dto := overbookingLimitDTO{RoomType: l.RoomType, Limit: l.Limit}
+		if !l.Date.IsZero() {
+			dto.Date = l.Date.String()
+		}
+		dtos = append(dtos, dto)
+	}
+	writeJSON(w, dtos)
+}
+
+func (h *OverbookingHandler) handleSet(w http.ResponseWriter, r *http.Request) {
+	var req overbookingLimitDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid body", http.StatusBadRequest)
+		return
+	}
+	date, err := parseOptionalDate(req.Date)
+	if err != nil {
+		http.Error(w, "invalid date", http.StatusBadRequest)
+		return
+	}
+
+	limit := bookingdomain.OverbookingLimit{RoomType: req.RoomType, Date: date, Limit: req.Limit}
+	if err := h.svc.SetOverbookingLimit(r.Context(), limit); err != nil {
+		status := http.StatusInternalServerError
+		if err == bookingapp.ErrInvalidOverbookingLimit {
+			status = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *OverbookingHandler) handleDelete(w http.ResponseWriter, r *http.Request) {
+	date, err := parseOptionalDate(r.URL.Query().Get("date"))
+	if err != nil {
+		http.Error(w, "invalid date", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.svc.DeleteOverbookingLimit(r.Context(), r.URL.Query().Get("roomType"), date); err != nil {
+		status := http.StatusInternalServerError
+		if err == bookingapp.ErrOverbookingLimitNotFound {
+			status = http.StatusNotFound
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func parseOptionalDate(value string) (propertydomain.Date, error) {
+	if value == "" {
+		return propertydomain.Date{}, nil
+	}
+	return propertydomain.ParseDate(value)
+}
@@ -0,0 +1,202 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	bookingapp "github.com/yourorg/hotel-api/internal/booking/app"
+	bookingdomain "github.com/yourorg/hotel-api/internal/booking/domain"
+	propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
+)
+
+// ReportsHandler serves operational booking reports for staff.
+type ReportsHandler struct {
+	svc *bookingapp.Service
+}
+
+func NewReportsHandler(svc *bookingapp.Service) *ReportsHandler {
+	return &ReportsHandler{svc: svc}
+}
+
+type oversoldNightDTO struct {
+	Date       string `json:"date"`
+	RoomType   string `json:"roomType"`
+	Rooms      int    `json:"rooms"`
+	Sold       int    `json:"sold"`
+	OversoldBy int    `json:"oversoldBy"`
+}
+
+type auditReportDTO struct {
+	Date                string   `json:"date"`
+	PropertyID          string   `json:"propertyId"`
+	RunAt               string   `json:"runAt"`
+	RunBy               string   `json:"runBy"`
+	RoomsAvailable      int      `json:"roomsAvailable"`
+	RoomsOccupied       int      `json:"roomsOccupied"`
+	Occupancy           float64  `json:"occupancy"`
+	Arrivals            int      `json:"arrivals"`
+	Departures          int      `json:"departures"`
+	RoomNightsPosted    int      `json:"roomNightsPosted"`
+	NoShows             []string `json:"noShows"`
+	UncheckedDepartures []string `json:"uncheckedDepartures"`
+	RoomRevenue         float64  `json:"roomRevenue"`
+	OtherRevenue        float64  `json:"otherRevenue"`
+	TaxCollected        float64  `json:"taxCollected"`
+	Payments            float64  `json:"payments"`
+	ADR                 float64  `json:"adr"`
+	RevPAR              float64  `json:"revPar"`
+}
+
+func toAuditReportDTO(r bookingdomain.AuditReport) auditReportDTO {
+	dto := auditReportDTO{
+		Date:                r.Date.String(),
+		PropertyID:          r.PropertyID,
+		RunAt:               r.RunAt.Format(time.RFC3339),
+		RunBy:               r.RunBy,
+		RoomsAvailable:      r.RoomsAvailable,
+		RoomsOccupied:       r.RoomsOccupied,
+		Occupancy:           r.Occupancy,
+		Arrivals:            r.Arrivals,
+		Departures:          r.Departures,
+		RoomNightsPosted:    r.RoomNightsPosted,
+		NoShows:             r.NoShows,
+		UncheckedDepartures: r.UncheckedDepartures,
+		RoomRevenue:         r.RoomRevenue,
+		OtherRevenue:        r.OtherRevenue,
+		TaxCollected:        r.TaxCollected,
+		Payments:            r.Payments,
+		ADR:                 r.ADR,
+		RevPAR:              r.RevPAR,
+	}
+	if dto.NoShows == nil {
+		dto.NoShows = []string{}
+	}
+	if dto.UncheckedDepartures == nil {
+		dto.UncheckedDepartures = []string{}
+	}
+	return dto
+}
+
+type outstandingDepositDTO struct {
+	BookingID        string  `json:"bookingId"`
+	ConfirmationCode string  `json:"confirmationCode"`
+	UserID           string  `json:"userId"`
+	CheckIn          string  `json:"checkIn"`
+	Installment      string  `json:"installment"`
+	DueAt            string  `json:"dueAt"`
+	Outstanding      float64 `json:"outstanding"`
+	Overdue          bool    `json:"overdue"`
+}
+
+func (h *ReportsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	path := strings.Trim(r.URL.Path, "/")
+	if strings.HasSuffix(path, "/oversold") {
+		h.handleOversold(w, r)
+		return
+	}
+	if strings.HasSuffix(path, "/deposits") {
+		h.handleDeposits(w, r)
+		return
+	}
+	if strings.HasSuffix(path, "/end-of-day") {
+		h.handleEndOfDay(w, r)
+		return
+	}
+
+	w.WriteHeader(http.StatusNotFound)
+}
+
+func (h *ReportsHandler) handleOversold(w http.ResponseWriter, r *http.Request) {
+	from, err1 := parseOptionalDate(r.URL.Query().Get("from"))
+	to, err2 := parseOptionalDate(r.URL.Query().Get("to"))
+	if err1 != nil || err2 != nil {
+		http.Error(w, "invalid from or to date", http.StatusBadRequest)
+		return
+	}
+
+	nights, err := h.svc.OversoldReport(r.Context(), from, to)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err == bookingapp.ErrInvalidDateRange {
+			status = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	dtos := make([]oversoldNightDTO, 0, len(nights))
+	for _, n := range nights {
+		dtos = append(dtos, oversoldNightDTO{
+			Date:       n.Date.String(),
+			RoomType:   n.RoomType,
+			Rooms:      n.Rooms,
+			Sold:       n.Sold,
+			OversoldBy: n.OversoldBy(),
+		})
+	}
+	writeJSON(w, dtos)
+}
+
+func (h *ReportsHandler) handleDeposits(w http.ResponseWriter, r *http.Request) {
+	deposits, err := h.svc.OutstandingDeposits(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	dtos := make([]outstandingDepositDTO, 0, len(deposits))
+	for _, d := range deposits {
+		dtos = append(dtos, outstandingDepositDTO{
+			BookingID:        d.Booking.ID,
+			ConfirmationCode: d.Booking.ConfirmationCode,
+			UserID:           d.Booking.UserID,
+			CheckIn:          d.Booking.CheckIn.String(),
+			Installment:      d.Next.Description,
+			DueAt:            d.Next.DueAt.Format(time.RFC3339),
+			Outstanding:      d.Outstanding,
+			Overdue:          d.Overdue,
+		})
+	}
+	writeJSON(w, dtos)
+}
+
+// handleEndOfDay returns the stored night audit report for ?date=, or all
+// reports when no date is given.
+func (h *ReportsHandler) handleEndOfDay(w http.ResponseWriter, r *http.Request) {
+	value := r.URL.Query().Get("date")
+	if value == "" {
+		reports, err := h.svc.AuditReports(r.Context())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		dtos := make([]auditReportDTO, 0, len(reports))
+		for _, report := range reports {
+			dtos = append(dtos, toAuditReportDTO(report))
+		}
+		writeJSON(w, dtos)
+		return
+	}
+
+	date, err := propertydomain.ParseDate(value)
+	if err != nil {
+		http.Error(w, "invalid date", http.StatusBadRequest)
+		return
+	}
+	report, err := h.svc.AuditReport(r.Context(), date)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if report == nil {
+		http.Error(w, "no end-of-day report for that date", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, toAuditReportDTO(*report))
+}
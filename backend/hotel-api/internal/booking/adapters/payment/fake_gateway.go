@@ -0,0 +1,116 @@
+package payment
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+
+	bookingports "github.com/yourorg/hotel-api/internal/booking/ports"
+)
+
+// FakeGateway is an in-memory PaymentGateway for local runs and tests.
+// Authorization IDs are sequential and any authorization above DeclineAbove
+// is declined, so behaviour is the same on every run.
+type FakeGateway struct {
+	mu           sync.Mutex
+	declineAbove float64
+	next         int
+	auths        map[string]*fakeAuthorization
+}
+
+type fakeAuthorization struct {
+	reference  string
+	authorized float64
+	captured   float64
+	refunded   float64
+	voided     bool
+}
+
+var _ bookingports.PaymentGateway = (*FakeGateway)(nil)
+
+// NewFakeGateway returns a gateway that declines authorizations above
+// declineAbove; zero accepts every amount.
+func NewFakeGateway(declineAbove float64) *FakeGateway {
+	return &FakeGateway{
+		declineAbove: declineAbove,
+		auths:        make(map[string]*fakeAuthorization),
+	}
+}
+
+func (g *FakeGateway) Authorize(ctx context.Context, reference string, amount float64) (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	if amount <= 0 || (g.declineAbove > 0 && amount > g.declineAbove) {
+		return "", bookingports.ErrPaymentDeclined
+	}
+
+	g.next++
+	id := fmt.Sprintf("auth-%06d", g.next)
+	g.auths[id] = &fakeAuthorization{reference: reference, authorized: amount}
+	return id, nil
+}
+
+func (g *FakeGateway) Capture(ctx context.Context, authorizationID string, amount float64) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	auth, err := g.find(ctx, authorizationID)
+	if err != nil {
+		return err
+	}
+	if auth.voided || auth.captured > 0 || amount <= 0 || round(amount) > auth.authorized {
+		return bookingports.ErrPaymentState
+	}
+	auth.captured = round(amount)
+	return nil
+}
+
+func (g *FakeGateway) Void(ctx context.Context, authorizationID string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	auth, err := g.find(ctx, authorizationID)
+	if err != nil {
+		return err
+	}
+	if auth.voided || auth.captured > 0 {
+		return bookingports.ErrPaymentState
+	}
+	auth.voided = true
+	return nil
+}
+
+func (g *FakeGateway) Refund(ctx context.Context, authorizationID string, amount float64) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	auth, err := g.find(ctx, authorizationID)
+	if err != nil {
+		return err
+	}
+	if amount <= 0 || round(auth.refunded+amount) > auth.captured {
+		return bookingports.ErrPaymentState
+	}
+	auth.refunded = round(auth.refunded + amount)
+	return nil
+}
+
+func (g *FakeGateway) find(ctx context.Context, authorizationID string) (*fakeAuthorization, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	auth, ok := g.auths[authorizationID]
+	if !ok {
+		return nil, bookingports.ErrAuthorizationUnknown
+	}
+	return auth, nil
+}
+
+func round(v float64) float64 {
+	return math.Round(v*100) / 100
+}
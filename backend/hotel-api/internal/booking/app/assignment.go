@@ -0,0 +1,266 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+
+	bookingdomain "github.com/yourorg/hotel-api/internal/booking/domain"
+	propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
+	roomapp "github.com/yourorg/hotel-api/internal/room/app"
+	roomdomain "github.com/yourorg/hotel-api/internal/room/domain"
+)
+
+// Room assignment strategies for bookings made by room type.
+const (
+	// AssignManual leaves assignment to staff at check-in.
+	AssignManual = "manual"
+	// AssignFirstAvailable picks the free room with the lowest ID.
+	AssignFirstAvailable = "first-available"
+	// AssignBestFit picks the free room with the smallest capacity that
+	// still fits the party, keeping larger rooms for larger groups.
+	AssignBestFit = "best-fit"
+)
+
+var (
+	ErrRoomRequired         = errors.New("room or room type required")
+	ErrRoomTypeNotFound     = errors.New("room type not found")
+	ErrRoomNotAssigned      = errors.New("booking has no room assigned")
+	ErrNoRoomToAssign       = errors.New("no free room of the booked type for the whole stay")
+	ErrRoomNotAssignable    = errors.New("room cannot be assigned to this booking")
+	ErrBookingNotAssignable = errors.New("booking can no longer be assigned a room")
+)
+
+// lockInventory locks the room type a new booking draws from until the
+// returned func is called.
+func (s *Service) lockInventory(ctx context.Context, req CreateRequest) (func(), error) {
+	roomType := req.RoomType
+	if req.RoomID != "" {
+		room, err := s.rooms.FindRoomByID(ctx, req.RoomID)
+		if err != nil {
+			return nil, err
+		}
+		if room == nil {
+			return nil, ErrRoomNotFound
+		}
+		roomType = room.Type
+	}
+
+	key := strings.ToLower(strings.TrimSpace(roomType))
+	s.typeLocksMu.Lock()
+	mu, ok := s.typeLocks[key]
+	if !ok {
+		mu = &sync.Mutex{}
+		s.typeLocks[key] = mu
+	}
+	s.typeLocksMu.Unlock()
+
+	mu.Lock()
+	return mu.Unlock, nil
+}
+
+// reserveInventory checks that the requested room, or a room of the
+// requested type, is free for the stay. A named room must itself be free;
+// only requests by room type may be sold up to the type's overbooking limit.
+// It returns the room to book, empty for room-type bookings, and the room
+// type.
+func (s *Service) reserveInventory(ctx context.Context, req CreateRequest) (string, string, error) {
+	if req.RoomID == "" && strings.TrimSpace(req.RoomType) == "" {
+		return "", "", ErrRoomRequired
+	}
+
+	inv, rooms, err := s.inventory(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	if req.RoomID != "" {
+		room, ok := findRoom(rooms, req.RoomID)
+		if !ok || !room.Sellable() {
+			return "", "", ErrRoomNotFound
+		}
+		if req.Guests > 0 && room.Capacity < req.Guests {
+			return "", "", ErrGuestsExceedRoom
+		}
+		if err := s.checkRestrictions(ctx, room.Type, req.CheckIn, req.CheckOut); err != nil {
+			return "", "", err
+		}
+		if !inv.RoomFree(room.ID, req.CheckIn, req.CheckOut, "") || inv.TypeAvailability(room.Type, req.CheckIn, req.CheckOut, "") <= 0 {
+			return "", "", ErrRoomUnavailable
+		}
+		return room.ID, room.Type, nil
+	}
+
+	roomType, fits, err := matchRoomType(rooms, req.RoomType, req.Guests)
+	if err != nil {
+		return "", "", err
+	}
+	if !fits {
+		return "", "", ErrGuestsExceedRoom
+	}
+	if err := s.checkRestrictions(ctx, roomType, req.CheckIn, req.CheckOut); err != nil {
+		return "", "", err
+	}
+	if inv.TypeAvailability(roomType, req.CheckIn, req.CheckOut, "") <= 0 {
+		return "", "", ErrRoomUnavailable
+	}
+	return "", roomType, nil
+}
+
+// checkRestrictions returns a *roomdomain.RestrictionError when the stay
+// breaks a stay restriction for the room type.
+func (s *Service) checkRestrictions(ctx context.Context, roomType string, checkIn, checkOut propertydomain.Date) error {
+	restrictions, err := s.restrictions.ListRestrictions(ctx)
+	if err != nil {
+		return err
+	}
+	if v := roomdomain.NewRestrictions(restrictions).Check(roomType, checkIn, checkOut); v != nil {
+		return v
+	}
+	return nil
+}
+
+// AssignRoom gives a booking a specific room for its whole stay. An empty
+// roomID lets the configured strategy choose. Assigning a room of another
+// type, for example as an upgrade, moves the booking to that type.
+func (s *Service) AssignRoom(ctx context.Context, bookingID, roomID string, meta ChangeMeta) (*bookingdomain.Booking, error) {
+	booking, err := s.bookings.FindByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if booking == nil {
+		return nil, ErrBookingNotFound
+	}
+	if !booking.BlocksInventory() || booking.Status == bookingdomain.StatusCheckedOut {
+		return nil, ErrBookingNotAssignable
+	}
+
+	before := *booking
+	if err := s.assign(ctx, booking, roomID); err != nil {
+		return nil, err
+	}
+	if err := s.bookings.Update(ctx, *booking); err != nil {
+		return nil, err
+	}
+	if err := s.record(ctx, bookingdomain.ActionRoomAssigned, &before, *booking, meta, false); err != nil {
+		return nil, err
+	}
+	return booking, nil
+}
+
+// assign sets booking.RoomID after checking the room is free for the stay.
+func (s *Service) assign(ctx context.Context, booking *bookingdomain.Booking, roomID string) error {
+	inv, rooms, err := s.inventory(ctx)
+	if err != nil {
+		return err
+	}
+
+	if roomID == "" {
+		room, ok := s.pickRoom(inv, rooms, *booking)
+		if !ok {
+			return ErrNoRoomToAssign
+		}
+		booking.RoomID = room.ID
+		booking.RoomType = room.Type
+		booking.Segments = nil
+		return nil
+	}
+
+	room, ok := findRoom(rooms, roomID)
+	if !ok {
+		return ErrRoomNotFound
+	}
+	if !room.Sellable() || (booking.Guests > 0 && room.Capacity < booking.Guests) {
+		return ErrRoomNotAssignable
+	}
+	if !inv.RoomFree(room.ID, booking.CheckIn, booking.CheckOut, booking.ID) {
+		return ErrRoomUnavailable
+	}
+	if !strings.EqualFold(room.Type, inv.RoomTypeOf(*booking)) &&
+		inv.TypeAvailability(room.Type, booking.CheckIn, booking.CheckOut, booking.ID) <= 0 {
+		return ErrRoomUnavailable
+	}
+
+	booking.RoomID = room.ID
+	booking.RoomType = room.Type
+	booking.Segments = nil
+	return nil
+}
+
+// pickRoom chooses a free room of the booking's type using the configured
+// strategy. Manual mode still picks the first available room when staff ask
+// for an automatic assignment explicitly.
+func (s *Service) pickRoom(inv bookingdomain.Inventory, rooms []roomdomain.Room, booking bookingdomain.Booking) (roomdomain.Room, bool) {
+	var candidates []roomdomain.Room
+	for _, r := range rooms {
+		if !strings.EqualFold(r.Type, booking.RoomType) || !r.Sellable() {
+			continue
+		}
+		if booking.Guests > 0 && r.Capacity < booking.Guests {
+			continue
+		}
+		if inv.RoomFree(r.ID, booking.CheckIn, booking.CheckOut, booking.ID) {
+			candidates = append(candidates, r)
+		}
+	}
+	if len(candidates) == 0 {
+		return roomdomain.Room{}, false
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if s.cfg.Assignment == AssignBestFit && candidates[i].Capacity != candidates[j].Capacity {
+			return candidates[i].Capacity < candidates[j].Capacity
+		}
+		return candidates[i].ID < candidates[j].ID
+	})
+	return candidates[0], true
+}
+
+func (s *Service) inventory(ctx context.Context) (bookingdomain.Inventory, []roomdomain.Room, error) {
+	rooms, err := s.rooms.ListRooms(ctx)
+	if err != nil {
+		return bookingdomain.Inventory{}, nil, err
+	}
+	bookings, err := s.bookings.List(ctx)
+	if err != nil {
+		return bookingdomain.Inventory{}, nil, err
+	}
+	limits, err := s.overbooking.ListOverbookingLimits(ctx)
+	if err != nil {
+		return bookingdomain.Inventory{}, nil, err
+	}
+	inv := bookingdomain.NewInventory(roomapp.InventoryRooms(rooms), bookings).WithOverbooking(bookingdomain.NewOverbookingPolicy(limits))
+	return inv, rooms, nil
+}
+
+func findRoom(rooms []roomdomain.Room, id string) (roomdomain.Room, bool) {
+	for _, r := range rooms {
+		if r.ID == id {
+			return r, true
+		}
+	}
+	return roomdomain.Room{}, false
+}
+
+// matchRoomType resolves the requested type to its stored spelling and
+// reports whether any sellable room of it fits the party.
+func matchRoomType(rooms []roomdomain.Room, roomType string, guests int) (string, bool, error) {
+	roomType = strings.TrimSpace(roomType)
+	var name string
+	fits := false
+	for _, r := range rooms {
+		if !strings.EqualFold(r.Type, roomType) || !r.Sellable() {
+			continue
+		}
+		name = r.Type
+		if guests <= 0 || r.Capacity >= guests {
+			fits = true
+		}
+	}
+	if name == "" {
+		return "", false, ErrRoomTypeNotFound
+	}
+	return name, fits, nil
+}
@@ -0,0 +1,135 @@
+package app
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"time"
+
+	bookingdomain "github.com/yourorg/hotel-api/internal/booking/domain"
+)
+
+var ErrInvalidCalendarToken = errors.New("invalid calendar token")
+
+// CalendarEvent is a booking as it appears in a guest's calendar. Start and
+// End are local property times.
+type CalendarEvent struct {
+	UID       string
+	Summary   string
+	Location  string
+	Details   string
+	Start     time.Time
+	End       time.Time
+	Cancelled bool
+	// Sequence increases every time the booking changes, so calendar apps
+	// replace their copy.
+	Sequence int
+	Stamp    time.Time
+}
+
+// BookingEvent returns the calendar event for one of the guest's bookings.
+func (s *Service) BookingEvent(ctx context.Context, bookingID, userID string) (*CalendarEvent, error) {
+	b, err := s.bookings.FindByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, ErrBookingNotFound
+	}
+	if b.UserID != userID {
+		return nil, ErrNotBookingOwner
+	}
+	event, err := s.calendarEvent(ctx, *b)
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// CalendarFeed returns the events for a guest's upcoming stays, including
+// cancelled ones and no-shows so subscribed calendars drop them.
+func (s *Service) CalendarFeed(ctx context.Context, token string) ([]CalendarEvent, error) {
+	userID, err := s.calendarUser(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	bookings, err := s.bookings.FindByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(bookings, func(i, j int) bool { return bookings[i].CheckIn.Before(bookings[j].CheckIn) })
+
+	today := s.today()
+	var events []CalendarEvent
+	for _, b := range bookings {
+		if b.CheckOut.Before(today) {
+			continue
+		}
+		event, err := s.calendarEvent(ctx, b)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// CalendarToken returns the secret token for the guest's subscription feed,
+// creating one the first time it is asked for.
+func (s *Service) CalendarToken(ctx context.Context, userID string) (string, error) {
+	return s.calendars.SaveCalendarToken(ctx, userID, randomCalendarToken(), false)
+}
+
+// RotateCalendarToken gives the guest a new feed token, so a feed URL that
+// was shared stops working.
+func (s *Service) RotateCalendarToken(ctx context.Context, userID string) (string, error) {
+	return s.calendars.SaveCalendarToken(ctx, userID, randomCalendarToken(), true)
+}
+
+func (s *Service) calendarUser(ctx context.Context, token string) (string, error) {
+	if token == "" {
+		return "", ErrInvalidCalendarToken
+	}
+	userID, err := s.calendars.FindCalendarUser(ctx, token)
+	if err != nil {
+		return "", err
+	}
+	if userID == "" {
+		return "", ErrInvalidCalendarToken
+	}
+	return userID, nil
+}
+
+func randomCalendarToken() string {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		panic("crypto/rand unavailable: " + err.Error())
+	}
+	return hex.EncodeToString(buf)
+}
+
+func (s *Service) calendarEvent(ctx context.Context, b bookingdomain.Booking) (CalendarEvent, error) {
+	history, err := s.history.ListHistory(ctx, b.ID)
+	if err != nil {
+		return CalendarEvent{}, err
+	}
+
+	property := s.cfg.Property
+	details := "Confirmation code: " + b.ConfirmationCode
+	if property.Phone != "" {
+		details += "\nPhone: " + property.Phone
+	}
+	return CalendarEvent{
+		UID:       b.ID + "@" + property.ID + ".hotel-api",
+		Summary:   "Stay at " + property.Name,
+		Location:  property.Address,
+		Details:   details,
+		Start:     s.atTimeOfDay(b.CheckIn, s.cfg.Stay.CheckInTime),
+		End:       s.atTimeOfDay(b.CheckOut, s.cfg.Stay.CheckOutTime),
+		Cancelled: !b.BlocksInventory(),
+		Sequence:  len(history),
+		Stamp:     s.clock.Now(),
+	}, nil
+}
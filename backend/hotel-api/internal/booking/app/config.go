@@ -0,0 +1,92 @@
+package app
+
+import (
+	"time"
+
+	bookingdomain "github.com/yourorg/hotel-api/internal/booking/domain"
+	propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
+)
+
+// Config holds the property policies the booking service applies.
+type Config struct {
+	Property propertydomain.Property
+	NoShow   NoShowPolicy
+	Stay     StayPolicy
+	Folio    FolioPolicy
+	Payment  PaymentPolicy
+	Deposit  DepositPolicy
+	// Assignment is the strategy used to give room-type bookings a room
+	// at check-in: AssignManual, AssignFirstAvailable or AssignBestFit.
+	Assignment string
+}
+
+// StayPolicy sets the standard arrival and departure times and what it costs
+// to arrive before or leave after them.
+type StayPolicy struct {
+	// CheckInTime and CheckOutTime are offsets from midnight.
+	CheckInTime  time.Duration
+	CheckOutTime time.Duration
+	// EarlyCheckInFee and LateCheckOutFee are fractions of the room's
+	// nightly price, so 0.5 charges half a night.
+	EarlyCheckInFee float64
+	LateCheckOutFee float64
+}
+
+// FolioPolicy sets how charges are taxed on the guest folio.
+type FolioPolicy struct {
+	// TaxRate is a fraction of each room and ad-hoc charge, so 0.1 adds 10%.
+	TaxRate float64
+}
+
+// PaymentPolicy decides what a cancelled booking still pays.
+type PaymentPolicy struct {
+	// FreeCancellation is how long before the standard check-in time on the
+	// arrival date a booking can be cancelled without charge.
+	FreeCancellation time.Duration
+	// LateCancellationFee is charged for later cancellations, as a number
+	// of nights of the stay's nightly rate.
+	LateCancellationFee float64
+}
+
+// DepositPolicy sets which stays pay up front and what happens when a
+// scheduled payment is missed.
+type DepositPolicy struct {
+	Rules []bookingdomain.DepositRule
+	// AutoCancel cancels bookings with an overdue payment instead of only
+	// flagging them.
+	AutoCancel bool
+}
+
+// NoShowPolicy decides when an unarrived booking becomes a no-show and what
+// it costs the guest.
+type NoShowPolicy struct {
+	// Cutoff is measured from midnight at the start of the day after arrival,
+	// so 2h means 02:00 the morning after the guest was due.
+	Cutoff time.Duration
+	// Fee is charged on the booking when it is marked as a no-show.
+	Fee float64
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Property: propertydomain.Property{
+			ID:            "main",
+			Name:          "Hotel",
+			InvoicePrefix: "INV",
+		},
+		NoShow: NoShowPolicy{
+			Cutoff: 2 * time.Hour,
+		},
+		Stay: StayPolicy{
+			CheckInTime:     15 * time.Hour,
+			CheckOutTime:    11 * time.Hour,
+			EarlyCheckInFee: 0.5,
+			LateCheckOutFee: 0.5,
+		},
+		Payment: PaymentPolicy{
+			FreeCancellation:    48 * time.Hour,
+			LateCancellationFee: 1,
+		},
+		Assignment: AssignFirstAvailable,
+	}
+}
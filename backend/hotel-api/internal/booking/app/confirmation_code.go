@@ -0,0 +1,61 @@
+package app
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"strings"
+)
+
+const (
+	confirmationCodePrefix = "SFX-"
+	confirmationCodeLength = 5
+	// Digits and capitals without 0/O, 1/I/L so codes survive being read
+	// out over the phone.
+	confirmationCodeAlphabet = "23456789ABCDEFGHJKMNPQRSTUVWXYZ"
+	maxCodeAttempts          = 10
+)
+
+var ErrCodeSpaceExhausted = errors.New("could not generate a unique confirmation code")
+
+// NormalizeConfirmationCode upper-cases a code typed by a guest or admin and
+// adds the property prefix if it was left off.
+func NormalizeConfirmationCode(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code != "" && !strings.HasPrefix(code, confirmationCodePrefix) {
+		code = confirmationCodePrefix + code
+	}
+	return code
+}
+
+func randomConfirmationCode() (string, error) {
+	max := big.NewInt(int64(len(confirmationCodeAlphabet)))
+	var sb strings.Builder
+	sb.WriteString(confirmationCodePrefix)
+	for i := 0; i < confirmationCodeLength; i++ {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteByte(confirmationCodeAlphabet[n.Int64()])
+	}
+	return sb.String(), nil
+}
+
+func (s *Service) newConfirmationCode(ctx context.Context) (string, error) {
+	for i := 0; i < maxCodeAttempts; i++ {
+		code, err := s.codeFn()
+		if err != nil {
+			return "", err
+		}
+		existing, err := s.bookings.FindByConfirmationCode(ctx, code)
+		if err != nil {
+			return "", err
+		}
+		if existing == nil {
+			return code, nil
+		}
+	}
+	return "", ErrCodeSpaceExhausted
+}
@@ -0,0 +1,219 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	bookingdomain "github.com/yourorg/hotel-api/internal/booking/domain"
+	bookingports "github.com/yourorg/hotel-api/internal/booking/ports"
+)
+
+var ErrNothingDue = errors.New("no payment is due on this booking")
+
+// OutstandingDeposit is a booking with scheduled payments still to make.
+type OutstandingDeposit struct {
+	Booking     bookingdomain.Booking
+	Next        bookingdomain.Installment
+	Outstanding float64
+	Overdue     bool
+}
+
+// buildSchedule sets the booking's prepayment schedule from the deposit rule
+// matching the stay, if any. Non-refundable stays are paid in full at
+// booking.
+func (s *Service) buildSchedule(ctx context.Context, b *bookingdomain.Booking, total float64) error {
+	if s.cancellationPolicy(*b).NonRefundable {
+		b.Schedule = []bookingdomain.Installment{{
+			ID:          b.ID + "-prepayment",
+			Description: "Prepayment",
+			Amount:      total,
+			DueAt:       b.CreatedAt,
+		}}
+		return nil
+	}
+
+	nights := len(bookingdomain.Nights(b.CheckIn, b.CheckOut))
+	rule, ok := bookingdomain.MatchDepositRule(s.cfg.Deposit.Rules, b.RoomType, nights)
+	if !ok {
+		return nil
+	}
+
+	deposit := total * rule.Percent
+	if rule.Nights > 0 {
+		value, err := s.nightsValue(ctx, *b, float64(rule.Nights))
+		if err != nil {
+			return err
+		}
+		deposit = value * (1 + s.cfg.Folio.TaxRate)
+	}
+	deposit = bookingdomain.RoundAmount(math.Min(deposit, total))
+	if deposit <= 0 {
+		return nil
+	}
+
+	depositDue := b.CreatedAt.AddDate(0, 0, rule.DueDays)
+	schedule := []bookingdomain.Installment{{
+		ID:          b.ID + "-deposit",
+		Description: "Deposit",
+		Amount:      deposit,
+		DueAt:       depositDue,
+	}}
+	if rest := bookingdomain.RoundAmount(total - deposit); rule.BalanceDueDays > 0 && rest > 0 {
+		balanceDue := s.atTimeOfDay(b.CheckIn.AddDays(-rule.BalanceDueDays), 0)
+		if balanceDue.Before(depositDue) {
+			balanceDue = depositDue
+		}
+		schedule = append(schedule, bookingdomain.Installment{
+			ID:          b.ID + "-balance",
+			Description: "Balance before arrival",
+			Amount:      rest,
+			DueAt:       balanceDue,
+		})
+	}
+	b.Schedule = schedule
+	return nil
+}
+
+// PayInstallment charges the guest for the next scheduled payment on their
+// booking and lowers the stay authorization by the same amount.
+func (s *Service) PayInstallment(ctx context.Context, bookingID, userID string) (*bookingdomain.Booking, error) {
+	b, err := s.bookings.FindByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, ErrBookingNotFound
+	}
+	if b.UserID != userID {
+		return nil, ErrNotBookingOwner
+	}
+	if b.Status != bookingdomain.StatusConfirmed {
+		return nil, ErrBookingNotModifiable
+	}
+	next, ok := b.NextInstallment()
+	if !ok {
+		return nil, ErrNothingDue
+	}
+
+	before := *b
+	amount := b.InstallmentOutstanding(next.ID)
+	if err := s.chargeCard(ctx, b, amount, next.Description, userID); err != nil {
+		return nil, err
+	}
+	// The charge has gone through; a hold that cannot be lowered now is
+	// still released at check-out, which never captures more than is owed.
+	_ = s.reduceHold(ctx, b, amount)
+	clearOverdue(b, s.clock.Now())
+	if err := s.bookings.Update(ctx, *b); err != nil {
+		return nil, err
+	}
+	if err := s.record(ctx, bookingdomain.ActionFolioPosted, &before, *b, ChangeMeta{Actor: userID}, false); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// ProcessOverdueDeposits flags confirmed bookings that missed a scheduled
+// payment, or cancels them when the deposit policy says so. It is safe to
+// run repeatedly.
+func (s *Service) ProcessOverdueDeposits(ctx context.Context) ([]bookingdomain.Booking, error) {
+	bookings, err := s.bookings.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	var processed []bookingdomain.Booking
+	for _, b := range bookings {
+		if b.Status != bookingdomain.StatusConfirmed || b.AmountDue(now) <= 0 {
+			continue
+		}
+		if b.DepositOverdue && !s.cfg.Deposit.AutoCancel {
+			continue
+		}
+
+		before := b
+		action := bookingdomain.ActionDepositOverdue
+		b.DepositOverdue = true
+		if s.cfg.Deposit.AutoCancel {
+			b.Status = bookingdomain.StatusCancelled
+			action = bookingdomain.ActionCancelled
+		}
+		// The booking is claimed from its confirmed status before any money
+		// moves; one changed since it was listed is left alone.
+		err := s.bookings.UpdateIfStatus(ctx, b, bookingdomain.StatusConfirmed)
+		if err == bookingports.ErrBookingChanged {
+			continue
+		}
+		if err != nil {
+			return processed, err
+		}
+		if b.Status == bookingdomain.StatusCancelled {
+			if err := s.chargeCancellation(ctx, &b, now, systemActor); err != nil {
+				_ = s.bookings.Update(ctx, before)
+				return processed, err
+			}
+			if err := s.bookings.Update(ctx, b); err != nil {
+				return processed, err
+			}
+		}
+		if b.Status == bookingdomain.StatusCancelled {
+			if err := s.releasePromo(ctx, b); err != nil {
+				return processed, err
+			}
+		}
+		meta := ChangeMeta{Actor: systemActor, Reason: "scheduled payment not received by its due date"}
+		if err := s.record(ctx, action, &before, b, meta, false); err != nil {
+			return processed, err
+		}
+		processed = append(processed, b)
+	}
+	return processed, nil
+}
+
+// OutstandingDeposits lists confirmed bookings with scheduled payments still
+// to make, overdue ones first, then by due date.
+func (s *Service) OutstandingDeposits(ctx context.Context) ([]OutstandingDeposit, error) {
+	bookings, err := s.bookings.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	var list []OutstandingDeposit
+	for _, b := range bookings {
+		if b.Status != bookingdomain.StatusConfirmed {
+			continue
+		}
+		next, ok := b.NextInstallment()
+		if !ok {
+			continue
+		}
+		list = append(list, OutstandingDeposit{
+			Booking:     b,
+			Next:        next,
+			Outstanding: b.InstallmentOutstanding(next.ID),
+			Overdue:     b.AmountDue(now) > 0,
+		})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Overdue != list[j].Overdue {
+			return list[i].Overdue
+		}
+		if !list[i].Next.DueAt.Equal(list[j].Next.DueAt) {
+			return list[i].Next.DueAt.Before(list[j].Next.DueAt)
+		}
+		return list[i].Booking.ID < list[j].Booking.ID
+	})
+	return list, nil
+}
+
+// clearOverdue lifts the overdue flag once nothing scheduled is unpaid.
+func clearOverdue(b *bookingdomain.Booking, now time.Time) {
+	if b.DepositOverdue && b.AmountDue(now) <= 0 {
+		b.DepositOverdue = false
+	}
+}
@@ -0,0 +1,148 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	bookingdomain "github.com/yourorg/hotel-api/internal/booking/domain"
+	propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
+	roomdomain "github.com/yourorg/hotel-api/internal/room/domain"
+)
+
+// BookingDetail is everything the guest app shows for one booking.
+type BookingDetail struct {
+	Booking bookingdomain.Booking
+	// Room is nil until a room is assigned to a room-type booking.
+	Room     *roomdomain.Room
+	Capacity int
+	Price    PriceBreakdown
+	// CancellationDeadline is the last moment the booking can be
+	// cancelled free of charge; CancellationFee is what cancelling now
+	// would cost.
+	CancellationDeadline time.Time
+	CancellationFee      float64
+	CanCancel            bool
+	CanModify            bool
+	CanPay               bool
+}
+
+// PriceBreakdown prices the stay night by night. Nights already posted to
+// the folio use the posted rate; the rest are estimated at today's rate.
+type PriceBreakdown struct {
+	Nights   []NightPrice
+	Room     float64
+	Discount float64
+	Extras   float64
+	Tax      float64
+	Total    float64
+	Paid     float64
+	Balance  float64
+}
+
+type NightPrice struct {
+	Date   propertydomain.Date
+	RoomID string
+	Rate   float64
+}
+
+// Detail returns a guest's booking with its room, price and what the guest
+// may still do with it.
+func (s *Service) Detail(ctx context.Context, bookingID, userID string) (*BookingDetail, error) {
+	b, err := s.bookings.FindByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, ErrBookingNotFound
+	}
+	if b.UserID != userID {
+		return nil, ErrNotBookingOwner
+	}
+
+	detail := &BookingDetail{Booking: *b}
+	if b.RoomID != "" {
+		room, err := s.rooms.FindRoomByID(ctx, b.RoomID)
+		if err != nil {
+			return nil, err
+		}
+		detail.Room = room
+	}
+	if detail.Capacity, err = s.typeCapacity(ctx, *b, detail.Room); err != nil {
+		return nil, err
+	}
+	if detail.Price, err = s.priceBreakdown(ctx, *b); err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	detail.CancellationDeadline = s.cancellationDeadline(*b)
+	detail.CanCancel = b.Status == bookingdomain.StatusConfirmed && !s.arrivalPassed(*b)
+	if detail.CanCancel {
+		if detail.CancellationFee, err = s.cancellationFee(ctx, *b, now); err != nil {
+			return nil, err
+		}
+	}
+	detail.CanModify = b.Modifiable()
+	_, due := b.NextInstallment()
+	detail.CanPay = b.Status == bookingdomain.StatusConfirmed && due
+	return detail, nil
+}
+
+func (s *Service) typeCapacity(ctx context.Context, b bookingdomain.Booking, room *roomdomain.Room) (int, error) {
+	if room != nil {
+		return room.Capacity, nil
+	}
+	rooms, err := s.rooms.ListRooms(ctx)
+	if err != nil {
+		return 0, err
+	}
+	capacity := 0
+	for _, r := range rooms {
+		if r.Type == b.RoomType && r.Capacity > capacity {
+			capacity = r.Capacity
+		}
+	}
+	return capacity, nil
+}
+
+func (s *Service) priceBreakdown(ctx context.Context, b bookingdomain.Booking) (PriceBreakdown, error) {
+	posted := map[propertydomain.Date]float64{}
+	var p PriceBreakdown
+	for _, l := range b.FolioLines() {
+		switch l.Kind {
+		case bookingdomain.FolioRoom:
+			posted[l.Night] = l.Amount
+		case bookingdomain.FolioDiscount:
+			p.Discount += l.Amount
+		case bookingdomain.FolioCharge, bookingdomain.FolioFee:
+			p.Extras += l.Amount
+		case bookingdomain.FolioTax:
+			p.Tax += l.Amount
+		}
+	}
+
+	for _, night := range bookingdomain.Nights(b.CheckIn, b.CheckOut) {
+		roomID := b.RoomOn(night)
+		rate, ok := posted[night]
+		if !ok {
+			var err error
+			if rate, err = s.rateOn(ctx, b, night); err != nil {
+				return PriceBreakdown{}, err
+			}
+			discount := b.DiscountFor(night)
+			p.Discount += discount
+			p.Tax += (rate - discount) * s.cfg.Folio.TaxRate
+		}
+		p.Nights = append(p.Nights, NightPrice{Date: night, RoomID: roomID, Rate: rate})
+		p.Room += rate
+	}
+
+	p.Room = bookingdomain.RoundAmount(p.Room)
+	p.Discount = bookingdomain.RoundAmount(p.Discount)
+	p.Extras = bookingdomain.RoundAmount(p.Extras)
+	p.Tax = bookingdomain.RoundAmount(p.Tax)
+	p.Total = bookingdomain.RoundAmount(p.Room - p.Discount + p.Extras + p.Tax)
+	p.Paid = b.Paid()
+	p.Balance = bookingdomain.RoundAmount(p.Total - p.Paid)
+	return p, nil
+}
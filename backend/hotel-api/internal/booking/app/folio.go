@@ -0,0 +1,190 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+
+	bookingdomain "github.com/yourorg/hotel-api/internal/booking/domain"
+	propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
+)
+
+var (
+	ErrInvalidFolioKind       = errors.New("folio postings must be a charge, payment or refund")
+	ErrInvalidFolioAmount     = errors.New("amount must be positive")
+	ErrDescriptionRequired    = errors.New("description required")
+	ErrRefundExceedsPayments  = errors.New("refund exceeds payments received")
+	ErrOutstandingBalance     = errors.New("folio has an outstanding balance")
+	ErrOverrideReasonRequired = errors.New("a reason is required to override")
+)
+
+// BalanceError reports how much is still owing on a folio that cannot be
+// closed. It matches ErrOutstandingBalance.
+type BalanceError struct {
+	Balance float64
+}
+
+func (e *BalanceError) Error() string { return ErrOutstandingBalance.Error() }
+
+func (e *BalanceError) Is(target error) bool { return target == ErrOutstandingBalance }
+
+// FolioPosting is a line staff post on a folio by hand.
+type FolioPosting struct {
+	Kind        string
+	Description string
+	Amount      float64
+}
+
+// Folio returns the booking whose folio lines and balance are wanted.
+func (s *Service) Folio(ctx context.Context, bookingID string) (*bookingdomain.Booking, error) {
+	b, err := s.bookings.FindByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, ErrBookingNotFound
+	}
+	return b, nil
+}
+
+// PostToFolio posts an ad-hoc charge, payment or refund. Charges are taxed
+// at the configured rate.
+func (s *Service) PostToFolio(ctx context.Context, bookingID string, posting FolioPosting, meta ChangeMeta) (*bookingdomain.FolioLine, error) {
+	posting.Description = strings.TrimSpace(posting.Description)
+	switch posting.Kind {
+	case bookingdomain.FolioCharge:
+		if posting.Description == "" {
+			return nil, ErrDescriptionRequired
+		}
+	case bookingdomain.FolioPayment, bookingdomain.FolioRefund:
+	default:
+		return nil, ErrInvalidFolioKind
+	}
+	amount := bookingdomain.RoundAmount(posting.Amount)
+	if amount <= 0 {
+		return nil, ErrInvalidFolioAmount
+	}
+
+	b, err := s.bookings.FindByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, ErrBookingNotFound
+	}
+	if posting.Kind == bookingdomain.FolioRefund && amount > b.Paid() {
+		return nil, ErrRefundExceedsPayments
+	}
+
+	before := *b
+	line := s.post(b, bookingdomain.FolioLine{
+		Kind:        posting.Kind,
+		Description: posting.Description,
+		Amount:      amount,
+	}, meta.Actor)
+	if posting.Kind == bookingdomain.FolioCharge {
+		s.postTax(b, line, meta.Actor)
+	}
+	clearOverdue(b, s.clock.Now())
+	if err := s.bookings.Update(ctx, *b); err != nil {
+		return nil, err
+	}
+	if err := s.record(ctx, bookingdomain.ActionFolioPosted, &before, *b, meta, false); err != nil {
+		return nil, err
+	}
+	return &line, nil
+}
+
+// postRoomCharges posts the room rate for every night of the stay that has
+// not been charged yet, catching up on nights the night audit did not post.
+func (s *Service) postRoomCharges(ctx context.Context, b *bookingdomain.Booking, poster string) error {
+	for _, night := range bookingdomain.Nights(b.CheckIn, b.CheckOut) {
+		if _, err := s.postRoomNight(ctx, b, night, poster); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// postRoomNight posts one night's room rate, its promo discount and tax on
+// what is left, using the room occupied on that night. It reports false if
+// the night was already charged.
+func (s *Service) postRoomNight(ctx context.Context, b *bookingdomain.Booking, night propertydomain.Date, poster string) (bool, error) {
+	if b.RoomCharged(night) {
+		return false, nil
+	}
+	room, err := s.rooms.FindRoomByID(ctx, b.RoomOn(night))
+	if err != nil {
+		return false, err
+	}
+	if room == nil {
+		return false, ErrRoomNotFound
+	}
+
+	rate, err := s.rateOn(ctx, *b, night)
+	if err != nil {
+		return false, err
+	}
+	line := s.post(b, bookingdomain.FolioLine{
+		Kind:        bookingdomain.FolioRoom,
+		Description: "Room " + room.Name,
+		Amount:      rate,
+		Night:       night,
+	}, poster)
+	taxable := line
+	if discount := math.Min(b.DiscountFor(night), rate); discount > 0 && b.Promo != nil {
+		s.post(b, bookingdomain.FolioLine{
+			Kind:        bookingdomain.FolioDiscount,
+			Description: "Promo " + b.Promo.Code,
+			Amount:      discount,
+			Night:       night,
+		}, poster)
+		taxable.Amount -= discount
+	}
+	s.postTax(b, taxable, poster)
+	return true, nil
+}
+
+// checkBalance refuses to close a folio that still has money owing unless
+// staff override with a reason.
+func checkBalance(balance float64, meta ChangeMeta) error {
+	if balance <= 0 {
+		return nil
+	}
+	if !meta.Override {
+		return &BalanceError{Balance: balance}
+	}
+	if strings.TrimSpace(meta.Reason) == "" {
+		return ErrOverrideReasonRequired
+	}
+	return nil
+}
+
+// post stamps the line and appends it to the booking's folio.
+func (s *Service) post(b *bookingdomain.Booking, line bookingdomain.FolioLine, poster string) bookingdomain.FolioLine {
+	if strings.TrimSpace(poster) == "" {
+		poster = systemActor
+	}
+	now := s.clock.Now()
+	line.ID = newID("folio")
+	line.PostedBy = poster
+	line.PostedAt = now
+	b.Folio = append(append([]bookingdomain.FolioLine(nil), b.Folio...), line)
+	return line
+}
+
+// postTax charges the configured tax rate on a line.
+func (s *Service) postTax(b *bookingdomain.Booking, line bookingdomain.FolioLine, poster string) {
+	tax := bookingdomain.RoundAmount(line.Amount * s.cfg.Folio.TaxRate)
+	if tax <= 0 {
+		return
+	}
+	s.post(b, bookingdomain.FolioLine{
+		Kind:        bookingdomain.FolioTax,
+		Description: "Tax on " + line.Description,
+		Amount:      tax,
+		Night:       line.Night,
+		TaxFor:      line.ID,
+	}, poster)
+}
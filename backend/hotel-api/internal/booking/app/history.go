@@ -0,0 +1,55 @@
+package app
+
+import (
+	"context"
+	"strings"
+
+	bookingdomain "github.com/yourorg/hotel-api/internal/booking/domain"
+)
+
+const systemActor = "system"
+
+// ChangeMeta describes who changed a booking and why. Override is set when
+// staff explicitly bypass a guard, such as checking out with an unpaid
+// balance, and needs a reason.
+type ChangeMeta struct {
+	Actor    string
+	Reason   string
+	Override bool
+}
+
+// actor is who made the change, the system when no one is named.
+func (m ChangeMeta) actor() string {
+	if actor := strings.TrimSpace(m.Actor); actor != "" {
+		return actor
+	}
+	return systemActor
+}
+
+// History returns the recorded mutations of a booking, oldest first.
+func (s *Service) History(ctx context.Context, bookingID string) ([]bookingdomain.HistoryEntry, error) {
+	b, err := s.bookings.FindByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, ErrBookingNotFound
+	}
+	return s.history.ListHistory(ctx, bookingID)
+}
+
+// record appends a history entry for the change from before to after. A nil
+// before records the creation of the booking.
+func (s *Service) record(ctx context.Context, action string, before *bookingdomain.Booking, after bookingdomain.Booking, meta ChangeMeta, override bool) error {
+	now := s.clock.Now()
+	return s.history.AppendHistory(ctx, bookingdomain.HistoryEntry{
+		ID:        newID("history"),
+		BookingID: after.ID,
+		Actor:     meta.actor(),
+		Action:    action,
+		Reason:    strings.TrimSpace(meta.Reason),
+		Override:  override || meta.Override,
+		At:        now,
+		Changes:   bookingdomain.Diff(before, after),
+	})
+}
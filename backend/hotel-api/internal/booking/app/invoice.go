@@ -0,0 +1,121 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	bookingdomain "github.com/yourorg/hotel-api/internal/booking/domain"
+	bookingports "github.com/yourorg/hotel-api/internal/booking/ports"
+)
+
+var (
+	ErrInvoiceNotAvailable     = errors.New("invoices are issued after check-out")
+	ErrInvoiceNotIssued        = errors.New("no invoice has been issued for this booking")
+	ErrNoInvoice               = errors.New("booking has no invoice to correct")
+	ErrCreditNoteReasonMissing = errors.New("a reason is required for a credit note")
+)
+
+// Invoice returns the booking's current invoice.
+func (s *Service) Invoice(ctx context.Context, bookingID string) (*bookingdomain.Invoice, error) {
+	docs, err := s.ListInvoices(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	current, ok := bookingdomain.CurrentInvoice(docs)
+	if !ok {
+		return nil, ErrInvoiceNotIssued
+	}
+	return &current, nil
+}
+
+// IssueInvoice issues an invoice from the folio of a checked-out booking,
+// first or again after a credit note. A booking that already has a current
+// invoice gets that one back rather than a second.
+func (s *Service) IssueInvoice(ctx context.Context, bookingID string, meta ChangeMeta) (*bookingdomain.Invoice, error) {
+	b, err := s.bookings.FindByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, ErrBookingNotFound
+	}
+	if b.Status != bookingdomain.StatusCheckedOut {
+		return nil, ErrInvoiceNotAvailable
+	}
+
+	inv := bookingdomain.Invoice{
+		Kind:       bookingdomain.InvoiceKindInvoice,
+		BookingID:  b.ID,
+		PropertyID: s.cfg.Property.ID,
+		IssuedAt:   s.clock.Now(),
+		IssuedBy:   meta.actor(),
+		Property:   s.cfg.Property,
+		StayFrom:   b.CheckIn,
+		StayTo:     b.CheckOut,
+		Lines:      b.InvoiceLines(),
+		Paid:       b.Paid(),
+	}
+	if inv.BillTo, err = s.billingDetails(ctx, b.UserID); err != nil {
+		return nil, err
+	}
+	inv.Totals()
+
+	issued, err := s.invoices.IssueInvoice(ctx, inv)
+	if err != nil {
+		return nil, err
+	}
+	return &issued, nil
+}
+
+// ListInvoices lists every invoice and credit note issued for the booking.
+func (s *Service) ListInvoices(ctx context.Context, bookingID string) ([]bookingdomain.Invoice, error) {
+	b, err := s.bookings.FindByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, ErrBookingNotFound
+	}
+	return s.invoices.ListInvoices(ctx, bookingID)
+}
+
+// IssueCreditNote cancels the booking's current invoice. The next call to
+// IssueInvoice issues a replacement from the folio as it then stands.
+func (s *Service) IssueCreditNote(ctx context.Context, bookingID string, meta ChangeMeta) (*bookingdomain.Invoice, error) {
+	if strings.TrimSpace(meta.Reason) == "" {
+		return nil, ErrCreditNoteReasonMissing
+	}
+	docs, err := s.ListInvoices(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	current, ok := bookingdomain.CurrentInvoice(docs)
+	if !ok {
+		return nil, ErrNoInvoice
+	}
+
+	note := current.CreditNote()
+	note.IssuedAt = s.clock.Now()
+	note.IssuedBy = meta.actor()
+	note.Reason = strings.TrimSpace(meta.Reason)
+	issued, err := s.invoices.IssueInvoice(ctx, note)
+	if err == bookingports.ErrInvoiceCredited {
+		return nil, ErrNoInvoice
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &issued, nil
+}
+
+func (s *Service) billingDetails(ctx context.Context, userID string) (bookingdomain.BillingDetails, error) {
+	user, err := s.users.FindUserByID(ctx, userID)
+	if err != nil {
+		return bookingdomain.BillingDetails{}, err
+	}
+	if user == nil {
+		return bookingdomain.BillingDetails{Name: userID}, nil
+	}
+	return bookingdomain.BillingDetails{Name: user.Name, Email: user.Email}, nil
+}
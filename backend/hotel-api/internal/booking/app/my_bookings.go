@@ -0,0 +1,132 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	bookingdomain "github.com/yourorg/hotel-api/internal/booking/domain"
+	propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
+)
+
+var ErrInvalidStage = errors.New("invalid status, use upcoming, current, past or cancelled")
+
+// GuestBookingsQuery lists one guest's bookings. An empty Stage returns all
+// of them, soonest arrival first for what is still ahead and most recent
+// first for the rest. A zero Limit returns every match.
+type GuestBookingsQuery struct {
+	UserID string
+	Stage  string
+	Cursor string
+	Limit  int
+}
+
+// GuestBooking is a booking with the stage it was listed under.
+type GuestBooking struct {
+	Booking bookingdomain.Booking
+	Stage   string
+}
+
+type GuestBookingsResult struct {
+	Bookings   []GuestBooking
+	Total      int
+	NextCursor string
+}
+
+func (s *Service) ListByUser(ctx context.Context, query GuestBookingsQuery) (*GuestBookingsResult, error) {
+	stage := strings.ToLower(strings.TrimSpace(query.Stage))
+	if stage != "" && !bookingdomain.ValidStage(stage) {
+		return nil, ErrInvalidStage
+	}
+	var after *GuestBooking
+	if query.Cursor != "" {
+		var cursor guestCursor
+		if err := decodeCursor(query.Cursor, &cursor); err != nil {
+			return nil, err
+		}
+		after = &GuestBooking{
+			Booking: bookingdomain.Booking{ID: cursor.ID, CheckIn: cursor.CheckIn, CheckOut: cursor.CheckOut},
+			Stage:   cursor.Stage,
+		}
+	}
+	limit := min(max(query.Limit, 0), maxSearchLimit)
+
+	bookings, err := s.bookings.FindByUser(ctx, query.UserID)
+	if err != nil {
+		return nil, err
+	}
+	today := s.today()
+	var matched []GuestBooking
+	for _, b := range bookings {
+		bs := b.Stage(today)
+		if stage == "" || bs == stage {
+			matched = append(matched, GuestBooking{Booking: b, Stage: bs})
+		}
+	}
+	slices.SortStableFunc(matched, compareGuestBookings)
+
+	result := &GuestBookingsResult{Total: len(matched)}
+	start := 0
+	if after != nil {
+		start, _ = slices.BinarySearchFunc(matched, *after, compareGuestBookings)
+		if start < len(matched) && compareGuestBookings(matched[start], *after) == 0 {
+			start++
+		}
+	}
+	end := len(matched)
+	if limit > 0 {
+		end = min(start+limit, len(matched))
+	}
+	result.Bookings = matched[start:end]
+	if end < len(matched) && end > start {
+		last := matched[end-1]
+		cursor := guestCursor{Stage: last.Stage, CheckIn: last.Booking.CheckIn, CheckOut: last.Booking.CheckOut, ID: last.Booking.ID}
+		if result.NextCursor, err = encodeCursor(cursor); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// guestCursor is the sort key of the last booking on a page of a guest's
+// bookings.
+type guestCursor struct {
+	Stage    string
+	CheckIn  propertydomain.Date
+	CheckOut propertydomain.Date
+	ID       string
+}
+
+// stageOrder lists stages in the order a mixed list shows them.
+var stageOrder = []string{
+	bookingdomain.StageCurrent,
+	bookingdomain.StageUpcoming,
+	bookingdomain.StagePast,
+	bookingdomain.StageCancelled,
+}
+
+// compareGuestBookings orders current stays by departure and upcoming ones
+// by arrival, soonest first; past stays by departure and cancelled bookings
+// by arrival, most recent first. Ties fall back to the booking ID so pages
+// are stable.
+func compareGuestBookings(a, b GuestBooking) int {
+	if c := slices.Index(stageOrder, a.Stage) - slices.Index(stageOrder, b.Stage); c != 0 {
+		return c
+	}
+	var c int
+	switch a.Stage {
+	case bookingdomain.StageCurrent:
+		c = a.Booking.CheckOut.Compare(b.Booking.CheckOut)
+	case bookingdomain.StageUpcoming:
+		c = a.Booking.CheckIn.Compare(b.Booking.CheckIn)
+	case bookingdomain.StagePast:
+		c = b.Booking.CheckOut.Compare(a.Booking.CheckOut)
+	default:
+		c = b.Booking.CheckIn.Compare(a.Booking.CheckIn)
+	}
+	if c == 0 {
+		c = strings.Compare(a.Booking.ID, b.Booking.ID)
+	}
+	return c
+}
@@ -0,0 +1,205 @@
+package app
+
+import (
+	"context"
+	"errors"
+
+	bookingdomain "github.com/yourorg/hotel-api/internal/booking/domain"
+	bookingports "github.com/yourorg/hotel-api/internal/booking/ports"
+	propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
+)
+
+var (
+	ErrAuditDayNotOpen = errors.New("business date has not reached that day yet")
+	ErrAuditTooEarly   = errors.New("cannot close a day that has not started")
+)
+
+// NightAudit closes a business date. It posts the night's room charge to
+// every in-house booking, marks confirmed arrivals that never came as
+// no-shows, flags guests still checked in past their departure date and
+// stores the end-of-day report. Auditing the current business date then
+// rolls it forward. Audits run one at a time, so a day is posted and
+// closed only once.
+// A zero date audits the current business date. A date that already has a
+// report is not audited again; its stored report is returned.
+func (s *Service) NightAudit(ctx context.Context, date propertydomain.Date, meta ChangeMeta) (*bookingdomain.AuditReport, error) {
+	s.auditMu.Lock()
+	defer s.auditMu.Unlock()
+
+	businessDate := s.today()
+	if date.IsZero() {
+		date = businessDate
+	}
+	if date.After(businessDate) {
+		return nil, ErrAuditDayNotOpen
+	}
+	if date.After(s.localDate(s.clock.Now())) {
+		return nil, ErrAuditTooEarly
+	}
+
+	existing, err := s.audits.FindAuditReport(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return existing, nil
+	}
+
+	bookings, err := s.bookings.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	actor := meta.actor()
+	report := bookingdomain.AuditReport{
+		Date:       date,
+		PropertyID: s.cfg.Property.ID,
+		RunAt:      s.clock.Now(),
+		RunBy:      actor,
+	}
+
+	for i := range bookings {
+		b := &bookings[i]
+		switch {
+		case b.Status == bookingdomain.StatusConfirmed && !b.CheckIn.After(date):
+			err := s.markNoShow(ctx, b, "no arrival by night audit")
+			if err == bookingports.ErrBookingChanged {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			report.NoShows = append(report.NoShows, b.ID)
+
+		case b.Status == bookingdomain.StatusCheckedIn && b.CheckOut.After(date):
+			posted, err := s.postRoomNight(ctx, b, date, actor)
+			if err != nil {
+				return nil, err
+			}
+			if posted {
+				report.RoomNightsPosted++
+				if err := s.bookings.Update(ctx, *b); err != nil {
+					return nil, err
+				}
+			}
+
+		case b.Status == bookingdomain.StatusCheckedIn:
+			report.UncheckedDepartures = append(report.UncheckedDepartures, b.ID)
+			if err := s.flagDepartureOverdue(ctx, b); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := s.countDay(ctx, &report, bookings); err != nil {
+		return nil, err
+	}
+	if err := s.audits.SaveAuditReport(ctx, report); err != nil {
+		return nil, err
+	}
+	if date == businessDate {
+		if _, err := s.clock.Advance(); err != nil {
+			return nil, err
+		}
+	}
+	return &report, nil
+}
+
+// CloseOpenDays runs the night audit for every business date the calendar
+// has moved past, oldest first, so the business date catches up after the
+// scheduled audit was missed. It returns the reports of the days it closed.
+func (s *Service) CloseOpenDays(ctx context.Context, meta ChangeMeta) ([]bookingdomain.AuditReport, error) {
+	var reports []bookingdomain.AuditReport
+	for {
+		date := s.today()
+		if !date.Before(s.localDate(s.clock.Now())) {
+			return reports, nil
+		}
+		report, err := s.NightAudit(ctx, date, meta)
+		if err != nil {
+			return reports, err
+		}
+		reports = append(reports, *report)
+		if s.today() == date {
+			// The day was already audited without being closed; running it
+			// again would not move the business date either.
+			return reports, nil
+		}
+	}
+}
+
+// AuditReport returns the stored end-of-day report for a date, or nil.
+func (s *Service) AuditReport(ctx context.Context, date propertydomain.Date) (*bookingdomain.AuditReport, error) {
+	return s.audits.FindAuditReport(ctx, date)
+}
+
+func (s *Service) AuditReports(ctx context.Context) ([]bookingdomain.AuditReport, error) {
+	return s.audits.ListAuditReports(ctx)
+}
+
+func (s *Service) flagDepartureOverdue(ctx context.Context, b *bookingdomain.Booking) error {
+	if b.DepartureOverdue {
+		return nil
+	}
+	before := *b
+	b.DepartureOverdue = true
+	if err := s.bookings.Update(ctx, *b); err != nil {
+		return err
+	}
+	meta := ChangeMeta{Actor: systemActor, Reason: "still checked in after departure date"}
+	return s.record(ctx, bookingdomain.ActionDepartureOverdue, &before, *b, meta, false)
+}
+
+// countDay fills in the report's occupancy and the revenue posted for the
+// day. Room nights and their tax count towards the night they are for;
+// other lines towards the hotel day they were posted on.
+func (s *Service) countDay(ctx context.Context, report *bookingdomain.AuditReport, bookings []bookingdomain.Booking) error {
+	rooms, err := s.rooms.ListRooms(ctx)
+	if err != nil {
+		return err
+	}
+	for _, r := range rooms {
+		if r.Status != "OUT_OF_ORDER" {
+			report.RoomsAvailable++
+		}
+	}
+
+	date := report.Date
+	for _, b := range bookings {
+		inHouse := b.Status == bookingdomain.StatusCheckedIn || b.Status == bookingdomain.StatusCheckedOut
+		if inHouse && b.CheckIn == date {
+			report.Arrivals++
+		}
+		if b.Status == bookingdomain.StatusCheckedOut && b.CheckOut == date {
+			report.Departures++
+		}
+		// Occupancy follows the nights the stay covered, so a day audited
+		// late still counts guests who have checked out since.
+		if inHouse && b.RoomOn(date) != "" {
+			report.RoomsOccupied++
+		}
+
+		for _, l := range b.FolioLines() {
+			day := s.localDate(l.PostedAt)
+			if !l.Night.IsZero() {
+				day = l.Night
+			}
+			if day != date {
+				continue
+			}
+			switch l.Kind {
+			case bookingdomain.FolioRoom:
+				report.RoomRevenue += l.Amount
+			case bookingdomain.FolioDiscount:
+				report.RoomRevenue -= l.Amount
+			case bookingdomain.FolioTax:
+				report.TaxCollected += l.Amount
+			case bookingdomain.FolioCharge, bookingdomain.FolioFee:
+				report.OtherRevenue += l.Amount
+			case bookingdomain.FolioPayment, bookingdomain.FolioRefund:
+				report.Payments -= l.Signed()
+			}
+		}
+	}
+	report.Totals()
+	return nil
+}
@@ -0,0 +1,74 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	bookingdomain "github.com/yourorg/hotel-api/internal/booking/domain"
+	bookingports "github.com/yourorg/hotel-api/internal/booking/ports"
+)
+
+// ProcessNoShows marks confirmed bookings whose arrival cut-off has passed
+// as no-shows, applies the configured fee, settles the payment for it and
+// releases the remaining nights.
+// It is safe to run repeatedly; already processed bookings are skipped, as
+// are bookings checked in or cancelled while the run is in progress.
+func (s *Service) ProcessNoShows(ctx context.Context) ([]bookingdomain.Booking, error) {
+	bookings, err := s.bookings.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	var processed []bookingdomain.Booking
+	for _, listed := range bookings {
+		if listed.Status != bookingdomain.StatusConfirmed || now.Before(s.noShowCutoff(listed)) {
+			continue
+		}
+		b, err := s.bookings.FindByID(ctx, listed.ID)
+		if err != nil {
+			return processed, err
+		}
+		if b == nil || b.Status != bookingdomain.StatusConfirmed {
+			continue
+		}
+		err = s.markNoShow(ctx, b, "arrival cut-off passed without check-in")
+		if err == bookingports.ErrBookingChanged {
+			continue
+		}
+		if err != nil {
+			return processed, err
+		}
+		processed = append(processed, *b)
+	}
+
+	return processed, nil
+}
+
+// markNoShow applies the no-show fee, settles the payment for it and
+// releases the booking's nights and promo code. The status is changed first and only if
+// the booking is still confirmed, so a guest who checks in meanwhile is never
+// charged; ErrBookingChanged is returned in that case.
+func (s *Service) markNoShow(ctx context.Context, b *bookingdomain.Booking, reason string) error {
+	before := *b
+	b.Status = bookingdomain.StatusNoShow
+	b.NoShowFee = s.cfg.NoShow.Fee
+	if err := s.bookings.UpdateIfStatus(ctx, *b, bookingdomain.StatusConfirmed); err != nil {
+		return err
+	}
+	if err := s.settlePayment(ctx, b, b.NoShowFee, systemActor); err != nil {
+		return err
+	}
+	if err := s.bookings.Update(ctx, *b); err != nil {
+		return err
+	}
+	if err := s.releasePromo(ctx, *b); err != nil {
+		return err
+	}
+	meta := ChangeMeta{Actor: systemActor, Reason: reason}
+	return s.record(ctx, bookingdomain.ActionNoShow, &before, *b, meta, false)
+}
+
+func (s *Service) noShowCutoff(b bookingdomain.Booking) time.Time {
+	return s.atTimeOfDay(b.CheckIn.AddDays(1), s.cfg.NoShow.Cutoff)
+}
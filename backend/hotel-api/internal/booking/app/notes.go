@@ -0,0 +1,135 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	bookingdomain "github.com/yourorg/hotel-api/internal/booking/domain"
+)
+
+const (
+	maxSpecialRequestsLength = 1000
+	maxStaffNoteLength       = 2000
+)
+
+var (
+	ErrNotBookingOwner         = errors.New("booking does not belong to this guest")
+	ErrBookingNotModifiable    = errors.New("booking can no longer be modified")
+	ErrSpecialRequestsTooLong  = errors.New("special requests are too long")
+	ErrStaffNoteRequired       = errors.New("note text required")
+	ErrStaffNoteTooLong        = errors.New("note text is too long")
+	ErrStaffNoteNotFound       = errors.New("note not found")
+	ErrStaffNoteAuthorRequired = errors.New("note author required")
+)
+
+// UpdateSpecialRequests replaces the guest-visible special requests on a
+// booking owned by userID.
+func (s *Service) UpdateSpecialRequests(ctx context.Context, bookingID, userID, text string) (*bookingdomain.Booking, error) {
+	text = strings.TrimSpace(text)
+	if len(text) > maxSpecialRequestsLength {
+		return nil, ErrSpecialRequestsTooLong
+	}
+
+	b, err := s.bookings.FindByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, ErrBookingNotFound
+	}
+	if b.UserID != userID {
+		return nil, ErrNotBookingOwner
+	}
+	if !b.Modifiable() {
+		return nil, ErrBookingNotModifiable
+	}
+
+	before := *b
+	b.SpecialRequests = text
+	if err := s.bookings.Update(ctx, *b); err != nil {
+		return nil, err
+	}
+	if err := s.record(ctx, bookingdomain.ActionSpecialRequestsUpdated, &before, *b, ChangeMeta{Actor: userID}, false); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (s *Service) ListStaffNotes(ctx context.Context, bookingID string) ([]bookingdomain.StaffNote, error) {
+	b, err := s.bookings.FindByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, ErrBookingNotFound
+	}
+	return b.StaffNotes, nil
+}
+
+// AddStaffNote appends a timestamped internal note to the booking.
+func (s *Service) AddStaffNote(ctx context.Context, bookingID, author, text string) (*bookingdomain.StaffNote, error) {
+	author = strings.TrimSpace(author)
+	text = strings.TrimSpace(text)
+	if author == "" {
+		return nil, ErrStaffNoteAuthorRequired
+	}
+	if text == "" {
+		return nil, ErrStaffNoteRequired
+	}
+	if len(text) > maxStaffNoteLength {
+		return nil, ErrStaffNoteTooLong
+	}
+
+	b, err := s.bookings.FindByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, ErrBookingNotFound
+	}
+
+	now := s.clock.Now()
+	note := bookingdomain.StaffNote{
+		ID:        newID("note"),
+		Author:    author,
+		Text:      text,
+		CreatedAt: now,
+	}
+	before := *b
+	b.StaffNotes = append(append([]bookingdomain.StaffNote(nil), b.StaffNotes...), note)
+	if err := s.bookings.Update(ctx, *b); err != nil {
+		return nil, err
+	}
+	if err := s.record(ctx, bookingdomain.ActionStaffNoteAdded, &before, *b, ChangeMeta{Actor: author}, false); err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+func (s *Service) DeleteStaffNote(ctx context.Context, bookingID, noteID string, meta ChangeMeta) error {
+	b, err := s.bookings.FindByID(ctx, bookingID)
+	if err != nil {
+		return err
+	}
+	if b == nil {
+		return ErrBookingNotFound
+	}
+
+	var kept []bookingdomain.StaffNote
+	for _, n := range b.StaffNotes {
+		if n.ID != noteID {
+			kept = append(kept, n)
+		}
+	}
+	if len(kept) == len(b.StaffNotes) {
+		return ErrStaffNoteNotFound
+	}
+
+	before := *b
+	b.StaffNotes = kept
+	if err := s.bookings.Update(ctx, *b); err != nil {
+		return err
+	}
+	return s.record(ctx, bookingdomain.ActionStaffNoteDeleted, &before, *b, meta, false)
+}
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	bookingdomain "github.com/yourorg/hotel-api/internal/booking/domain"
+	propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
+)
+
+var (
+	ErrInvalidOverbookingLimit  = errors.New("overbooking limit must not be negative")
+	ErrOverbookingLimitNotFound = errors.New("overbooking limit not found")
+)
+
+// OverbookingLimits lists the configured limits, global ones first.
+func (s *Service) OverbookingLimits(ctx context.Context) ([]bookingdomain.OverbookingLimit, error) {
+	limits, err := s.overbooking.ListOverbookingLimits(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(limits, func(i, j int) bool {
+		if limits[i].RoomType != limits[j].RoomType {
+			return limits[i].RoomType < limits[j].RoomType
+		}
+		return limits[i].Date.Before(limits[j].Date)
+	})
+	return limits, nil
+}
+
+// SetOverbookingLimit creates or replaces the limit for a room type and date.
+// Leave the room type empty for all types and the date zero for all dates.
+func (s *Service) SetOverbookingLimit(ctx context.Context, limit bookingdomain.OverbookingLimit) error {
+	if limit.Limit < 0 {
+		return ErrInvalidOverbookingLimit
+	}
+	limit.RoomType = strings.TrimSpace(limit.RoomType)
+	return s.overbooking.SaveOverbookingLimit(ctx, limit)
+}
+
+func (s *Service) DeleteOverbookingLimit(ctx context.Context, roomType string, date propertydomain.Date) error {
+	limits, err := s.overbooking.ListOverbookingLimits(ctx)
+	if err != nil {
+		return err
+	}
+	for _, l := range limits {
+		if strings.EqualFold(l.RoomType, strings.TrimSpace(roomType)) && l.Date == date {
+			return s.overbooking.DeleteOverbookingLimit(ctx, l.RoomType, l.Date)
+		}
+	}
+	return ErrOverbookingLimitNotFound
+}
+
+// defaultReportDays is how far ahead a report looks when no end date is
+// given.
+const defaultReportDays = 30
+
+// OversoldReport lists the nights between from and to where a room type is
+// currently sold beyond its physical rooms. From defaults to the property's
+// today and to to defaultReportDays later.
+func (s *Service) OversoldReport(ctx context.Context, from, to propertydomain.Date) ([]bookingdomain.OversoldNight, error) {
+	if from.IsZero() {
+		from = s.today()
+	}
+	if to.IsZero() {
+		to = from.AddDays(defaultReportDays)
+	}
+	if !to.After(from) {
+		return nil, ErrInvalidDateRange
+	}
+	inv, _, err := s.inventory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	nights := inv.Oversold(from, to)
+	sort.Slice(nights, func(i, j int) bool {
+		if nights[i].Date != nights[j].Date {
+			return nights[i].Date.Before(nights[j].Date)
+		}
+		return nights[i].RoomType < nights[j].RoomType
+	})
+	return nights, nil
+}
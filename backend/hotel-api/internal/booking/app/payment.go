@@ -0,0 +1,282 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	bookingdomain "github.com/yourorg/hotel-api/internal/booking/domain"
+	bookingports "github.com/yourorg/hotel-api/internal/booking/ports"
+)
+
+var ErrPaymentDeclined = errors.New("payment was declined")
+
+// takePayment prices the stay, sets up its deposit schedule and charges
+// whatever is due at booking time. The rest of the stay is authorized on the
+// guest's payment method.
+func (s *Service) takePayment(ctx context.Context, b *bookingdomain.Booking) error {
+	total, err := s.stayTotal(ctx, *b)
+	if err != nil {
+		return err
+	}
+	if total <= 0 {
+		return nil
+	}
+	if err := s.buildSchedule(ctx, b, total); err != nil {
+		return err
+	}
+
+	upfront := b.AmountDue(b.CreatedAt)
+	if remaining := bookingdomain.RoundAmount(total - upfront); remaining > 0 {
+		authID, err := s.payments.Authorize(ctx, b.ID, remaining)
+		if err != nil {
+			return paymentError(err)
+		}
+		b.Payment = bookingdomain.PaymentState{
+			AuthorizationID: authID,
+			Status:          bookingdomain.PaymentAuthorized,
+			Authorized:      remaining,
+		}
+	}
+	if upfront > 0 {
+		if err := s.chargeCard(ctx, b, upfront, "Deposit", b.UserID); err != nil {
+			if b.Payment.Open() {
+				_ = s.payments.Void(ctx, b.Payment.AuthorizationID)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// chargeCard takes amount from the guest straight away and posts it to the
+// folio as a payment.
+func (s *Service) chargeCard(ctx context.Context, b *bookingdomain.Booking, amount float64, description, poster string) error {
+	authID, err := s.payments.Authorize(ctx, b.ID, amount)
+	if err != nil {
+		return paymentError(err)
+	}
+	if err := s.payments.Capture(ctx, authID, amount); err != nil {
+		_ = s.payments.Void(ctx, authID)
+		return paymentError(err)
+	}
+	s.post(b, bookingdomain.FolioLine{
+		Kind:            bookingdomain.FolioPayment,
+		Description:     description + " " + authID,
+		Amount:          amount,
+		AuthorizationID: authID,
+	}, poster)
+	return nil
+}
+
+// refundCharges gives back up to amount of what chargeCard took, newest
+// charge first, and posts the refunds to the folio.
+func (s *Service) refundCharges(ctx context.Context, b *bookingdomain.Booking, amount float64, poster string) error {
+	amount = bookingdomain.RoundAmount(amount)
+	refunded := map[string]float64{}
+	for _, l := range b.Folio {
+		if l.Kind == bookingdomain.FolioRefund {
+			refunded[l.AuthorizationID] += l.Amount
+		}
+	}
+	for i := len(b.Folio) - 1; i >= 0 && amount > 0; i-- {
+		l := b.Folio[i]
+		if l.Kind != bookingdomain.FolioPayment || l.AuthorizationID == "" || l.AuthorizationID == b.Payment.AuthorizationID {
+			continue
+		}
+		refund := bookingdomain.RoundAmount(math.Min(amount, l.Amount-refunded[l.AuthorizationID]))
+		if refund <= 0 {
+			continue
+		}
+		if err := s.payments.Refund(ctx, l.AuthorizationID, refund); err != nil {
+			return err
+		}
+		refunded[l.AuthorizationID] += refund
+		amount = bookingdomain.RoundAmount(amount - refund)
+		s.post(b, bookingdomain.FolioLine{
+			Kind:            bookingdomain.FolioRefund,
+			Description:     "Card refund " + l.AuthorizationID,
+			Amount:          refund,
+			AuthorizationID: l.AuthorizationID,
+		}, poster)
+	}
+	return nil
+}
+
+// abandonPayment undoes the hold, card charges and promo redemption taken
+// for a booking that could not be completed.
+func (s *Service) abandonPayment(ctx context.Context, b *bookingdomain.Booking) {
+	if b.Payment.Open() {
+		if err := s.payments.Void(ctx, b.Payment.AuthorizationID); err == nil {
+			b.Payment.Status = bookingdomain.PaymentVoided
+		}
+	}
+	_ = s.refundCharges(ctx, b, b.Paid(), systemActor)
+	_ = s.releasePromo(ctx, *b)
+}
+
+func paymentError(err error) error {
+	if err == bookingports.ErrPaymentDeclined {
+		return ErrPaymentDeclined
+	}
+	return err
+}
+
+// cancellationPolicy is the policy of the booking's rate plan, or the
+// property's payment policy for bookings without one.
+func (s *Service) cancellationPolicy(b bookingdomain.Booking) bookingdomain.CancellationTerms {
+	if b.RatePlan != nil {
+		return b.RatePlan.Cancellation
+	}
+	return bookingdomain.CancellationTerms{
+		FreeCancellation: s.cfg.Payment.FreeCancellation,
+		FeeNights:        s.cfg.Payment.LateCancellationFee,
+	}
+}
+
+// cancellationDeadline is the last moment the booking can be cancelled free
+// of charge. Non-refundable bookings were never free to cancel.
+func (s *Service) cancellationDeadline(b bookingdomain.Booking) time.Time {
+	policy := s.cancellationPolicy(b)
+	if policy.NonRefundable {
+		return b.CreatedAt
+	}
+	return s.atTimeOfDay(b.CheckIn, s.cfg.Stay.CheckInTime).Add(-policy.FreeCancellation)
+}
+
+// cancellationFee is what cancelling at now costs under the booking's
+// cancellation policy. Non-refundable bookings forfeit the whole stay.
+func (s *Service) cancellationFee(ctx context.Context, b bookingdomain.Booking, now time.Time) (float64, error) {
+	policy := s.cancellationPolicy(b)
+	if policy.NonRefundable {
+		return s.roomTotal(ctx, b, len(bookingdomain.Nights(b.CheckIn, b.CheckOut)))
+	}
+	if now.Before(s.cancellationDeadline(b)) || policy.FeeNights <= 0 {
+		return 0, nil
+	}
+	return s.nightsValue(ctx, b, policy.FeeNights)
+}
+
+// settlePayment closes the booking's payment keeping only amount: an open
+// authorization is captured for amount or voided, and anything captured
+// beyond amount is refunded. Money moved is posted to the folio.
+func (s *Service) settlePayment(ctx context.Context, b *bookingdomain.Booking, amount float64, poster string) error {
+	p := b.Payment
+	if p.AuthorizationID == "" {
+		return nil
+	}
+	amount = bookingdomain.RoundAmount(amount)
+
+	switch {
+	case p.Open() && amount > 0:
+		capture := math.Min(amount, p.Authorized)
+		if err := s.payments.Capture(ctx, p.AuthorizationID, capture); err != nil {
+			return err
+		}
+		p.Status = bookingdomain.PaymentCaptured
+		p.Captured = capture
+		s.post(b, bookingdomain.FolioLine{
+			Kind:            bookingdomain.FolioPayment,
+			Description:     "Card payment " + p.AuthorizationID,
+			Amount:          capture,
+			AuthorizationID: p.AuthorizationID,
+		}, poster)
+	case p.Open():
+		if err := s.payments.Void(ctx, p.AuthorizationID); err != nil {
+			return err
+		}
+		p.Status = bookingdomain.PaymentVoided
+	case p.Status == bookingdomain.PaymentCaptured && p.Captured-p.Refunded > amount:
+		refund := bookingdomain.RoundAmount(p.Captured - p.Refunded - amount)
+		if err := s.payments.Refund(ctx, p.AuthorizationID, refund); err != nil {
+			return err
+		}
+		p.Refunded = bookingdomain.RoundAmount(p.Refunded + refund)
+		if p.Refunded >= p.Captured {
+			p.Status = bookingdomain.PaymentRefunded
+		}
+		s.post(b, bookingdomain.FolioLine{
+			Kind:            bookingdomain.FolioRefund,
+			Description:     "Card refund " + p.AuthorizationID,
+			Amount:          refund,
+			AuthorizationID: p.AuthorizationID,
+		}, poster)
+	}
+	b.Payment = p
+	return nil
+}
+
+// outstandingAfterCapture is the balance left once the open authorization,
+// if any, has been captured against it.
+func outstandingAfterCapture(b bookingdomain.Booking) float64 {
+	balance := b.Balance()
+	if b.Payment.Open() {
+		balance -= math.Min(math.Max(balance, 0), b.Payment.Authorized)
+	}
+	return bookingdomain.RoundAmount(balance)
+}
+
+// chargeCancellation adds the cancellation fee to the booking and settles
+// the payment for it.
+func (s *Service) chargeCancellation(ctx context.Context, b *bookingdomain.Booking, now time.Time, poster string) error {
+	fee, err := s.cancellationFee(ctx, *b, now)
+	if err != nil {
+		return err
+	}
+	if fee > 0 {
+		b.Fees = append(append([]bookingdomain.Fee(nil), b.Fees...), bookingdomain.Fee{
+			ID:        newID("fee"),
+			Kind:      bookingdomain.FeeCancellation,
+			Amount:    fee,
+			ChargedAt: now,
+		})
+	}
+	// Deposits already paid count towards the fee and the stay
+	// authorization only covers what is left; deposits beyond the fee are
+	// given back.
+	p := b.Payment
+	deposits := b.Paid() - (p.Captured - p.Refunded)
+	if err := s.settlePayment(ctx, b, math.Max(fee-deposits, 0), poster); err != nil {
+		return err
+	}
+	if deposits > fee {
+		return s.refundCharges(ctx, b, deposits-fee, poster)
+	}
+	return nil
+}
+
+// reduceHold lowers the stay authorization by amount once that part of the
+// stay has been paid separately. The gateway cannot shrink a hold, so it is
+// voided and authorized again for what is left.
+func (s *Service) reduceHold(ctx context.Context, b *bookingdomain.Booking, amount float64) error {
+	p := b.Payment
+	if !p.Open() || amount <= 0 {
+		return nil
+	}
+	remaining := bookingdomain.RoundAmount(p.Authorized - amount)
+	var authID string
+	if remaining > 0 {
+		var err error
+		if authID, err = s.payments.Authorize(ctx, b.ID, remaining); err != nil {
+			return paymentError(err)
+		}
+	}
+	if err := s.payments.Void(ctx, p.AuthorizationID); err != nil {
+		if authID != "" {
+			_ = s.payments.Void(ctx, authID)
+		}
+		return err
+	}
+	if authID == "" {
+		p.Status = bookingdomain.PaymentVoided
+		b.Payment = p
+		return nil
+	}
+	b.Payment = bookingdomain.PaymentState{
+		AuthorizationID: authID,
+		Status:          bookingdomain.PaymentAuthorized,
+		Authorized:      remaining,
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package app
+
+import (
+	"context"
+	"errors"
+
+	bookingdomain "github.com/yourorg/hotel-api/internal/booking/domain"
+	roomdomain "github.com/yourorg/hotel-api/internal/room/domain"
+	roomports "github.com/yourorg/hotel-api/internal/room/ports"
+)
+
+var ErrPromoNotFound = errors.New("promo code not found")
+
+// applyPromo checks the promo code against the stay, the rate plan it is
+// sold under and the guest, and spreads its discount over the booking's
+// nightly rates. calendar holds the nightly rates before the plan. No code
+// means no promo.
+func (s *Service) applyPromo(ctx context.Context, b *bookingdomain.Booking, plan *roomdomain.RatePlan, calendar []float64, code string) (*roomdomain.Promo, error) {
+	code = roomdomain.NormalizePromoCode(code)
+	if code == "" {
+		return nil, nil
+	}
+	promo, err := s.promos.FindPromo(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if promo == nil || !promo.Active {
+		return nil, ErrPromoNotFound
+	}
+	if v := promo.CheckStay(s.today(), b.CheckIn, b.CheckOut); v != nil {
+		return nil, v
+	}
+	if v := promo.CheckRate(b.RoomType, plan, calendar, b.Guests); v != nil {
+		return nil, v
+	}
+	if promo.FirstBookingOnly {
+		previous, err := s.bookings.FindByUser(ctx, b.UserID)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range previous {
+			if p.Status != bookingdomain.StatusCancelled {
+				return nil, &roomdomain.PromoError{Code: roomdomain.PromoFirstBookingOnly}
+			}
+		}
+	}
+
+	prices := make([]float64, 0, len(b.Rates))
+	for _, r := range b.Rates {
+		prices = append(prices, r.Amount)
+	}
+	total := 0.0
+	for i, d := range promo.Discounts(prices) {
+		b.Rates[i].Discount = d
+		total += d
+	}
+	b.Promo = &bookingdomain.AppliedPromo{Code: promo.Code, Discount: bookingdomain.RoundAmount(total)}
+	return promo, nil
+}
+
+// redeemPromo counts the booking's use of its promo code, failing when the
+// code's usage limits are used up.
+func (s *Service) redeemPromo(ctx context.Context, b bookingdomain.Booking, promo *roomdomain.Promo) error {
+	if promo == nil {
+		return nil
+	}
+	err := s.promos.RedeemPromo(ctx, roomdomain.PromoRedemption{
+		Code:       promo.Code,
+		UserID:     b.UserID,
+		BookingID:  b.ID,
+		RedeemedAt: s.clock.Now(),
+	})
+	switch err {
+	case roomports.ErrPromoLimitReached:
+		return &roomdomain.PromoError{Code: roomdomain.PromoLimitReached, Limit: promo.MaxUses}
+	case roomports.ErrPromoGuestLimit:
+		return &roomdomain.PromoError{Code: roomdomain.PromoGuestLimit, Limit: promo.MaxUsesPerGuest}
+	}
+	return err
+}
+
+// releasePromo gives back the booking's use of its promo code once the stay
+// will not take place, so it no longer counts towards the code's limits.
+func (s *Service) releasePromo(ctx context.Context, b bookingdomain.Booking) error {
+	if b.Promo == nil {
+		return nil
+	}
+	return s.promos.ReleasePromo(ctx, b.Promo.Code, b.ID)
+}
@@ -0,0 +1,174 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+
+	bookingdomain "github.com/yourorg/hotel-api/internal/booking/domain"
+	propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
+	roomapp "github.com/yourorg/hotel-api/internal/room/app"
+	roomdomain "github.com/yourorg/hotel-api/internal/room/domain"
+)
+
+var (
+	ErrRatePlanNotFound   = errors.New("rate plan not found")
+	ErrRatePlanNotOffered = errors.New("rate plan is not offered for this room type")
+)
+
+// offeredPlan looks up the rate plan a stay is booked under and checks it
+// is sold for the room type on the channel. No plan ID means none.
+func (s *Service) offeredPlan(ctx context.Context, planID, roomType, channel string) (*roomdomain.RatePlan, error) {
+	if strings.TrimSpace(planID) == "" {
+		return nil, nil
+	}
+	plan, err := s.plans.FindRatePlan(ctx, strings.TrimSpace(planID))
+	if err != nil {
+		return nil, err
+	}
+	if plan == nil {
+		return nil, ErrRatePlanNotFound
+	}
+	if channel == "" {
+		channel = roomdomain.ChannelWeb
+	}
+	if !plan.Offered(roomType, channel) {
+		return nil, ErrRatePlanNotOffered
+	}
+	return plan, nil
+}
+
+// bookedPlan snapshots the terms of the plan a stay is sold under.
+func bookedPlan(plan *roomdomain.RatePlan) *bookingdomain.BookedPlan {
+	if plan == nil {
+		return nil
+	}
+	return &bookingdomain.BookedPlan{
+		ID:         plan.ID,
+		Name:       plan.Name,
+		Inclusions: append([]string(nil), plan.Inclusions...),
+		Cancellation: bookingdomain.CancellationTerms{
+			NonRefundable:    plan.Cancellation.NonRefundable,
+			FreeCancellation: plan.Cancellation.FreeCancellation,
+			FeeNights:        plan.Cancellation.FeeNights,
+		},
+	}
+}
+
+// partyOf splits the booking's guests into adults and children. Create
+// only stores parties PartyOf accepts, so anything else is an error rather
+// than a guess.
+func partyOf(b bookingdomain.Booking) (roomdomain.Party, error) {
+	party, ok := roomdomain.PartyOf(b.Guests, b.ChildAges)
+	if !ok {
+		return roomdomain.Party{}, roomdomain.ErrInvalidParty
+	}
+	return party, nil
+}
+
+// quoteStay prices each night of the stay for the party from the rate
+// calendar and extra-guest charges, in the given room or, when none is
+// chosen, the type's room that is cheapest for the whole stay.
+func (s *Service) quoteStay(ctx context.Context, roomID, roomType string, party roomdomain.Party, checkIn, checkOut propertydomain.Date) ([]bookingdomain.NightRate, error) {
+	calendar, err := roomapp.LoadRateCalendar(ctx, s.rates)
+	if err != nil {
+		return nil, err
+	}
+	occupancy, err := s.occupancy.FindOccupancyPricing(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rooms, err := s.rooms.ListRooms(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var best []roomdomain.NightlyRate
+	bestTotal := 0.0
+	for _, room := range rooms {
+		switch {
+		case roomID != "":
+			if room.ID != roomID {
+				continue
+			}
+		case !strings.EqualFold(room.Type, roomType) || !room.Sellable():
+			continue
+		}
+		nightly, _ := calendar.Stay(room, checkIn, checkOut)
+		nightly, total := occupancy.Stay(room.Type, party, nightly)
+		if best == nil || total < bestTotal {
+			best, bestTotal = nightly, total
+		}
+	}
+	if best == nil {
+		return nil, ErrRoomNotFound
+	}
+
+	rates := make([]bookingdomain.NightRate, 0, len(best))
+	for _, n := range best {
+		rates = append(rates, bookingdomain.NightRate{Night: n.Date, Amount: n.Price})
+	}
+	return rates, nil
+}
+
+// rateOn is the room rate for one night of the booking: the rate agreed at
+// booking, or for bookings without one, today's rate for the party in the
+// room occupied that night.
+func (s *Service) rateOn(ctx context.Context, b bookingdomain.Booking, night propertydomain.Date) (float64, error) {
+	if rate, ok := b.RateFor(night); ok {
+		return rate, nil
+	}
+	party, err := partyOf(b)
+	if err != nil {
+		return 0, err
+	}
+	rates, err := s.quoteStay(ctx, b.RoomOn(night), b.RoomType, party, night, night.AddDays(1))
+	if err != nil {
+		return 0, err
+	}
+	return rates[0].Amount, nil
+}
+
+// nightsValue is the room rate, less promo discounts, of the first nights
+// of the stay, with a fractional last night counted pro rata.
+func (s *Service) nightsValue(ctx context.Context, b bookingdomain.Booking, nights float64) (float64, error) {
+	total := 0.0
+	for i, night := range bookingdomain.Nights(b.CheckIn, b.CheckOut) {
+		share := math.Min(1, nights-float64(i))
+		if share <= 0 {
+			break
+		}
+		rate, err := s.rateOn(ctx, b, night)
+		if err != nil {
+			return 0, err
+		}
+		total += (rate - b.DiscountFor(night)) * share
+	}
+	return bookingdomain.RoundAmount(total), nil
+}
+
+// roomTotal is the room charges for the first nights of the stay including
+// tax.
+func (s *Service) roomTotal(ctx context.Context, b bookingdomain.Booking, nights int) (float64, error) {
+	value, err := s.nightsValue(ctx, b, float64(nights))
+	if err != nil {
+		return 0, err
+	}
+	return bookingdomain.RoundAmount(value * (1 + s.cfg.Folio.TaxRate)), nil
+}
+
+// stayTotal is what the whole stay costs: its room charges including tax
+// and the fees booked on it that were not waived.
+func (s *Service) stayTotal(ctx context.Context, b bookingdomain.Booking) (float64, error) {
+	total, err := s.roomTotal(ctx, b, len(bookingdomain.Nights(b.CheckIn, b.CheckOut)))
+	if err != nil {
+		return 0, err
+	}
+	for _, f := range b.Fees {
+		if !f.Waived {
+			total += f.Amount
+		}
+	}
+	return bookingdomain.RoundAmount(total), nil
+}
@@ -0,0 +1,72 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	bookingdomain "github.com/yourorg/hotel-api/internal/booking/domain"
+	propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
+)
+
+var ErrInvalidMoveDate = errors.New("move date must fall within the stay")
+
+// MoveRoom moves the guest to another room from the given date until
+// check-out, splitting the stay into segments. The target room must be free
+// for all remaining nights.
+func (s *Service) MoveRoom(ctx context.Context, bookingID, roomID string, from propertydomain.Date, meta ChangeMeta) (*bookingdomain.Booking, error) {
+	booking, err := s.bookings.FindByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if booking == nil {
+		return nil, ErrBookingNotFound
+	}
+	if !booking.BlocksInventory() || booking.Status == bookingdomain.StatusCheckedOut {
+		return nil, ErrBookingNotAssignable
+	}
+	if booking.RoomID == "" {
+		return nil, ErrRoomNotAssigned
+	}
+	if from.Before(booking.CheckIn) || !from.Before(booking.CheckOut) {
+		return nil, ErrInvalidMoveDate
+	}
+
+	inv, rooms, err := s.inventory(ctx)
+	if err != nil {
+		return nil, err
+	}
+	target, ok := findRoom(rooms, roomID)
+	if !ok {
+		return nil, ErrRoomNotFound
+	}
+	if target.ID == booking.RoomOn(from) {
+		return nil, ErrRoomNotAssignable
+	}
+	if !target.Sellable() || (booking.Guests > 0 && target.Capacity < booking.Guests) {
+		return nil, ErrRoomNotAssignable
+	}
+	if !inv.RoomFree(target.ID, from, booking.CheckOut, booking.ID) {
+		return nil, ErrRoomUnavailable
+	}
+	if !strings.EqualFold(target.Type, inv.RoomTypeOf(*booking)) &&
+		inv.TypeAvailability(target.Type, from, booking.CheckOut, booking.ID) <= 0 {
+		return nil, ErrRoomUnavailable
+	}
+
+	before := *booking
+	if from == booking.CheckIn {
+		booking.Segments = nil
+	} else {
+		booking.Segments = booking.MoveFrom(target.ID, from)
+	}
+	booking.RoomID = target.ID
+
+	if err := s.bookings.Update(ctx, *booking); err != nil {
+		return nil, err
+	}
+	if err := s.record(ctx, bookingdomain.ActionRoomMoved, &before, *booking, meta, false); err != nil {
+		return nil, err
+	}
+	return booking, nil
+}
@@ -0,0 +1,186 @@
+package app
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	bookingdomain "github.com/yourorg/hotel-api/internal/booking/domain"
+	bookingports "github.com/yourorg/hotel-api/internal/booking/ports"
+	propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
+)
+
+const maxSearchLimit = 200
+
+var (
+	ErrInvalidSort   = errors.New("invalid sort")
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
+
+// SearchFilters are the admin booking overview filters. Empty fields do not
+// filter.
+type SearchFilters struct {
+	Statuses    []string
+	RoomID      string
+	RoomType    string
+	Guest       string // matches guest email or name
+	From        *propertydomain.Date
+	To          *propertydomain.Date
+	ArrivalOn   *propertydomain.Date
+	DepartureOn *propertydomain.Date
+	Code        string
+	Text        string
+	// Sort is a field name, prefixed with "-" for descending order.
+	Sort   string
+	Cursor string
+	// Limit pages the result; zero returns every match.
+	Limit int
+}
+
+type SearchResult struct {
+	Bookings   []bookingdomain.Booking
+	Total      int
+	NextCursor string
+}
+
+func (s *Service) Search(ctx context.Context, filters SearchFilters) (*SearchResult, error) {
+	query := bookingports.BookingQuery{
+		Statuses:    filters.Statuses,
+		From:        filters.From,
+		To:          filters.To,
+		ArrivalOn:   filters.ArrivalOn,
+		DepartureOn: filters.DepartureOn,
+		Code:        NormalizeConfirmationCode(filters.Code),
+		Text:        filters.Text,
+	}
+
+	sortField, desc, err := parseSort(filters.Sort)
+	if err != nil {
+		return nil, err
+	}
+	query.Sort = sortField
+	query.Descending = desc
+
+	if filters.Cursor != "" {
+		var cursor searchCursor
+		if err := decodeCursor(filters.Cursor, &cursor); err != nil || cursor.Sort != filters.Sort {
+			return nil, ErrInvalidCursor
+		}
+		query.After = &cursor.After
+	}
+	query.Limit = min(max(filters.Limit, 0), maxSearchLimit)
+
+	query.RoomIDs, err = s.resolveRoomIDs(ctx, filters.RoomID, filters.RoomType)
+	if err != nil {
+		return nil, err
+	}
+	query.UserIDs, err = s.resolveUserIDs(ctx, filters.Guest)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := s.bookings.Search(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SearchResult{Bookings: page.Bookings, Total: page.Total}
+	if page.More && len(page.Bookings) > 0 {
+		last := page.Bookings[len(page.Bookings)-1]
+		result.NextCursor, err = encodeCursor(searchCursor{Sort: filters.Sort, After: bookingports.KeyOf(last)})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// searchCursor resumes a search after the last booking of the previous
+// page. It is only valid for the sort it was issued with.
+type searchCursor struct {
+	Sort  string
+	After bookingports.BookingKey
+}
+
+// resolveRoomIDs narrows the room filter to concrete IDs. A nil result means
+// any room; an empty non-nil result means no room matched.
+func (s *Service) resolveRoomIDs(ctx context.Context, roomID, roomType string) ([]string, error) {
+	roomID = strings.TrimSpace(roomID)
+	roomType = strings.TrimSpace(roomType)
+	if roomType == "" {
+		if roomID == "" {
+			return nil, nil
+		}
+		return []string{roomID}, nil
+	}
+
+	rooms, err := s.rooms.ListRooms(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ids := []string{}
+	for _, r := range rooms {
+		if !strings.EqualFold(r.Type, roomType) {
+			continue
+		}
+		if roomID != "" && r.ID != roomID {
+			continue
+		}
+		ids = append(ids, r.ID)
+	}
+	return ids, nil
+}
+
+func (s *Service) resolveUserIDs(ctx context.Context, guest string) ([]string, error) {
+	guest = strings.TrimSpace(guest)
+	if guest == "" {
+		return nil, nil
+	}
+
+	users, err := s.users.FindUsers(ctx, guest)
+	if err != nil {
+		return nil, err
+	}
+	ids := []string{}
+	for _, u := range users {
+		ids = append(ids, u.ID)
+	}
+	return ids, nil
+}
+
+func parseSort(value string) (string, bool, error) {
+	desc := strings.HasPrefix(value, "-")
+	field := strings.TrimPrefix(value, "-")
+	switch field {
+	case "":
+		return bookingports.SortByCheckIn, desc, nil
+	case bookingports.SortByCheckIn, bookingports.SortByCheckOut, bookingports.SortByCreatedAt,
+		bookingports.SortByStatus, bookingports.SortByID:
+		return field, desc, nil
+	default:
+		return "", false, ErrInvalidSort
+	}
+}
+
+// Cursors are opaque to clients; they carry the sort key of the last
+// booking on the previous page.
+func encodeCursor(position any) (string, error) {
+	raw, err := json.Marshal(position)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+func decodeCursor(cursor string, position any) error {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+	if err := json.Unmarshal(raw, position); err != nil {
+		return ErrInvalidCursor
+	}
+	return nil
+}
@@ -3,11 +3,16 @@ package app
 import (
 	"context"
 	"errors"
-	"fmt"
+	"strings"
+	"sync"
 	"time"
 
+	authports "github.com/yourorg/hotel-api/internal/auth/ports"
 	bookingdomain "github.com/yourorg/hotel-api/internal/booking/domain"
 	bookingports "github.com/yourorg/hotel-api/internal/booking/ports"
+	propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
+	propertyports "github.com/yourorg/hotel-api/internal/property/ports"
+	roomdomain "github.com/yourorg/hotel-api/internal/room/domain"
 	roomports "github.com/yourorg/hotel-api/internal/room/ports"
 )
 
@@ -20,133 +25,240 @@ var (
 	ErrRoomNotFound     = errors.New("room not found")
 	ErrTooEarlyCheckIn  = errors.New("cannot check in before the check-in date")
 	ErrTooEarlyCheckOut = errors.New("cannot check out before the check-out date")
+	ErrNotCheckedIn     = errors.New("booking is not checked in")
+	ErrNotConfirmed     = errors.New("booking is not confirmed")
 )
 
 type Service struct {
-	bookings bookingports.BookingRepository
-	rooms    roomports.RoomRepository
-	nowFn    func() time.Time
+	bookings     bookingports.BookingRepository
+	history      bookingports.HistoryRepository
+	invoices     bookingports.InvoiceRepository
+	audits       bookingports.AuditRepository
+	calendars    bookingports.CalendarTokenRepository
+	overbooking  bookingports.OverbookingRepository
+	rooms        roomports.RoomRepository
+	restrictions roomports.RestrictionRepository
+	rates        roomports.RateRepository
+	plans        roomports.RatePlanRepository
+	promos       roomports.PromoRepository
+	occupancy    roomports.OccupancyRepository
+	payments     bookingports.PaymentGateway
+	users        authports.UserRepository
+	clock        propertyports.DayCloser
+	cfg          Config
+	codeFn       func() (string, error)
+	auditMu      sync.Mutex
+	typeLocksMu  sync.Mutex
+	typeLocks    map[string]*sync.Mutex
 }
 
-func NewService(bookings bookingports.BookingRepository, rooms roomports.RoomRepository) *Service {
+func NewService(bookings bookingports.BookingRepository, history bookingports.HistoryRepository, invoices bookingports.InvoiceRepository, audits bookingports.AuditRepository, calendars bookingports.CalendarTokenRepository, overbooking bookingports.OverbookingRepository, rooms roomports.RoomRepository, restrictions roomports.RestrictionRepository, rates roomports.RateRepository, plans roomports.RatePlanRepository, promos roomports.PromoRepository, occupancy roomports.OccupancyRepository, payments bookingports.PaymentGateway, users authports.UserRepository, clock propertyports.DayCloser, cfg Config) *Service {
 	return &Service{
-		bookings: bookings,
-		rooms:    rooms,
-		nowFn:    time.Now,
+		bookings:     bookings,
+		history:      history,
+		invoices:     invoices,
+		audits:       audits,
+		calendars:    calendars,
+		overbooking:  overbooking,
+		rooms:        rooms,
+		restrictions: restrictions,
+		rates:        rates,
+		plans:        plans,
+		promos:       promos,
+		occupancy:    occupancy,
+		payments:     payments,
+		users:        users,
+		clock:        clock,
+		cfg:          cfg,
+		codeFn:       randomConfirmationCode,
+		typeLocks:    map[string]*sync.Mutex{},
 	}
 }
 
+// CreateRequest books either a specific room (RoomID) or any room of a type
+// (RoomType), in which case the room is assigned later. Guests counts
+// everyone staying and ChildAges the age of each child among them, which
+// prices extra guests beyond the room's base occupancy; a pair that leaves
+// no adult is rejected. RatePlanID sells the stay under a rate plan offered
+// on Channel, which defaults to the web; without one the stay is booked at
+// the calendar rate. PromoCode takes a campaign discount off the room rates.
+// Any deposit due at booking is charged and the rest of the stay authorized
+// on the guest's payment method.
 type CreateRequest struct {
-	UserID   string
-	RoomID   string
-	CheckIn  time.Time
-	CheckOut time.Time
-	Guests   int
+	UserID          string
+	RoomID          string
+	RoomType        string
+	CheckIn         propertydomain.Date
+	CheckOut        propertydomain.Date
+	Guests          int
+	ChildAges       []int
+	SpecialRequests string
+	RatePlanID      string
+	Channel         string
+	PromoCode       string
 }
 
 type CreateResponse struct {
-	ID       string
-	RoomID   string
-	CheckIn  time.Time
-	CheckOut time.Time
-	Status   string
-}
-
-type ListFilters struct {
-	From *time.Time
-	To   *time.Time
+	ID               string
+	ConfirmationCode string
+	RoomID           string
+	RoomType         string
+	Guests           int
+	ChildAges        []int
+	CheckIn          propertydomain.Date
+	CheckOut         propertydomain.Date
+	Status           string
+	SpecialRequests  string
+	RatePlan         *bookingdomain.BookedPlan
+	Promo            *bookingdomain.AppliedPromo
+	Payment          bookingdomain.PaymentState
+	Schedule         []bookingdomain.Installment
+	Folio            []bookingdomain.FolioLine
 }
 
 func (s *Service) Create(ctx context.Context, req CreateRequest) (*CreateResponse, error) {
 	if req.CheckIn.IsZero() || req.CheckOut.IsZero() || !req.CheckOut.After(req.CheckIn) {
 		return nil, ErrInvalidDateRange
 	}
-
-	rooms, err := s.rooms.SearchAvailable(ctx, roomports.SearchParams{
-		CheckIn:  req.CheckIn,
-		CheckOut: req.CheckOut,
-		Guests:   req.Guests,
-	})
+	horizon, err := s.restrictions.FindBookingHorizon(ctx)
 	if err != nil {
 		return nil, err
 	}
-
-	var roomFound bool
-	for _, r := range rooms {
-		if r.ID == req.RoomID {
-			roomFound = true
-			if req.Guests > 0 && r.Capacity < req.Guests {
-				return nil, ErrGuestsExceedRoom
-			}
-			break
-		}
+	if v := horizon.Check(s.today(), s.clock.Now(), s.cfg.Property.Location(), req.CheckIn); v != nil {
+		return nil, v
 	}
-	if !roomFound {
-		return nil, ErrRoomNotFound
+	specialRequests := strings.TrimSpace(req.SpecialRequests)
+	if len(specialRequests) > maxSpecialRequestsLength {
+		return nil, ErrSpecialRequestsTooLong
+	}
+	party, ok := roomdomain.PartyOf(req.Guests, req.ChildAges)
+	if !ok {
+		return nil, roomdomain.ErrInvalidParty
 	}
 
-	allBookings, err := s.bookings.List(ctx)
+	// The room type stays locked until the booking is stored, so two
+	// bookings cannot both be sold the type's last room.
+	unlock, err := s.lockInventory(ctx, req)
 	if err != nil {
 		return nil, err
 	}
-	for _, b := range allBookings {
-		if b.RoomID == req.RoomID && overlaps(req.CheckIn, req.CheckOut, b.CheckIn, b.CheckOut) {
-			return nil, ErrRoomUnavailable
-		}
+	defer unlock()
+	roomID, roomType, err := s.reserveInventory(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := s.offeredPlan(ctx, req.RatePlanID, roomType, req.Channel)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := s.newConfirmationCode(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	id := fmt.Sprintf("booking-%d", s.nowFn().UnixNano())
+	id := newID("booking")
 	newBooking := bookingdomain.Booking{
-		ID:        id,
-		UserID:    req.UserID,
-		RoomID:    req.RoomID,
-		CheckIn:   req.CheckIn,
-		CheckOut:  req.CheckOut,
-		Status:    "confirmed",
-		CreatedAt: s.nowFn(),
+		ID:               id,
+		ConfirmationCode: code,
+		UserID:           req.UserID,
+		RoomID:           roomID,
+		RoomType:         roomType,
+		Guests:           party.Size(),
+		ChildAges:        party.ChildAges,
+		CheckIn:          req.CheckIn,
+		CheckOut:         req.CheckOut,
+		Status:           bookingdomain.StatusConfirmed,
+		SpecialRequests:  specialRequests,
+		RatePlan:         bookedPlan(plan),
+		CreatedAt:        s.clock.Now(),
+	}
+	if newBooking.Rates, err = s.quoteStay(ctx, roomID, roomType, party, req.CheckIn, req.CheckOut); err != nil {
+		return nil, err
+	}
+	calendar := make([]float64, 0, len(newBooking.Rates))
+	for i, r := range newBooking.Rates {
+		calendar = append(calendar, r.Amount)
+		if plan != nil {
+			newBooking.Rates[i].Amount = plan.Price.Apply(r.Amount, party.Size())
+		}
+	}
+	promo, err := s.applyPromo(ctx, &newBooking, plan, calendar, req.PromoCode)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.redeemPromo(ctx, newBooking, promo); err != nil {
+		return nil, err
+	}
+	if err := s.takePayment(ctx, &newBooking); err != nil {
+		_ = s.releasePromo(ctx, newBooking)
+		return nil, err
 	}
 
 	if err := s.bookings.Create(ctx, newBooking); err != nil {
+		s.abandonPayment(ctx, &newBooking)
+		return nil, err
+	}
+	if err := s.record(ctx, bookingdomain.ActionCreated, nil, newBooking, ChangeMeta{Actor: req.UserID}, false); err != nil {
+		// The guest is told the booking failed, so it must not keep their
+		// money or the room.
+		s.abandonPayment(ctx, &newBooking)
+		newBooking.Status = bookingdomain.StatusCancelled
+		_ = s.bookings.Update(ctx, newBooking)
 		return nil, err
 	}
 
 	return &CreateResponse{
-		ID:       id,
-		RoomID:   req.RoomID,
-		CheckIn:  req.CheckIn,
-		CheckOut: req.CheckOut,
-		Status:   newBooking.Status,
+		ID:               id,
+		ConfirmationCode: code,
+		RoomID:           roomID,
+		RoomType:         roomType,
+		Guests:           newBooking.Guests,
+		ChildAges:        newBooking.ChildAges,
+		CheckIn:          req.CheckIn,
+		CheckOut:         req.CheckOut,
+		Status:           newBooking.Status,
+		SpecialRequests:  newBooking.SpecialRequests,
+		RatePlan:         newBooking.RatePlan,
+		Promo:            newBooking.Promo,
+		Payment:          newBooking.Payment,
+		Schedule:         newBooking.Schedule,
+		Folio:            newBooking.Folio,
 	}, nil
 }
 
-func (s *Service) ListByUser(ctx context.Context, userID string) ([]bookingdomain.Booking, error) {
-	return s.bookings.FindByUser(ctx, userID)
-}
+// Lookup finds a booking by confirmation code for a guest who is not signed
+// in. The email must belong to the booking's guest; any mismatch is reported
+// as ErrBookingNotFound so codes cannot be probed for validity.
+func (s *Service) Lookup(ctx context.Context, code, email string) (*bookingdomain.Booking, error) {
+	code = NormalizeConfirmationCode(code)
+	email = strings.ToLower(strings.TrimSpace(email))
+	if code == "" || email == "" {
+		return nil, ErrBookingNotFound
+	}
 
-func (s *Service) List(ctx context.Context, filters ListFilters) ([]bookingdomain.Booking, error) {
-	bookings, err := s.bookings.List(ctx)
+	b, err := s.bookings.FindByConfirmationCode(ctx, code)
 	if err != nil {
 		return nil, err
 	}
+	if b == nil {
+		return nil, ErrBookingNotFound
+	}
 
-	var result []bookingdomain.Booking
-	for _, b := range bookings {
-		checkInDate := startOfDay(b.CheckIn)
-		checkOutDate := startOfDay(b.CheckOut)
-
-		if filters.From != nil && checkInDate.Before(startOfDay(*filters.From)) {
-			continue
-		}
-		if filters.To != nil && checkOutDate.After(endOfDay(*filters.To)) {
-			continue
-		}
-		result = append(result, b)
+	user, err := s.users.FindByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || user.ID != b.UserID {
+		return nil, ErrBookingNotFound
 	}
 
-	return result, nil
+	return b, nil
 }
 
-func (s *Service) Cancel(ctx context.Context, bookingID string) error {
+// Cancel cancels a confirmed booking before its arrival date, charging any
+// cancellation fee its terms set.
+func (s *Service) Cancel(ctx context.Context, bookingID string, meta ChangeMeta) error {
 	b, err := s.bookings.FindByID(ctx, bookingID)
 	if err != nil {
 		return err
@@ -155,16 +267,52 @@ func (s *Service) Cancel(ctx context.Context, bookingID string) error {
 		return ErrBookingNotFound
 	}
 
-	now := s.nowFn()
-	if b.CheckIn.Before(now) {
+	if b.Status != bookingdomain.StatusConfirmed {
+		return ErrNotConfirmed
+	}
+	if s.arrivalPassed(*b) {
 		return ErrCannotCancelPast
 	}
 
-	b.Status = "cancelled"
-	return s.bookings.Update(ctx, *b)
+	// The booking is claimed as cancelled before any money moves, so a
+	// concurrent change cannot leave it active with its payment released.
+	before := *b
+	b.Status = bookingdomain.StatusCancelled
+	if err := s.bookings.UpdateIfStatus(ctx, *b, bookingdomain.StatusConfirmed); err != nil {
+		return err
+	}
+	if err := s.chargeCancellation(ctx, b, s.clock.Now(), meta.Actor); err != nil {
+		_ = s.bookings.Update(ctx, before)
+		return err
+	}
+	if err := s.bookings.Update(ctx, *b); err != nil {
+		return err
+	}
+	if err := s.releasePromo(ctx, *b); err != nil {
+		return err
+	}
+	return s.record(ctx, bookingdomain.ActionCancelled, &before, *b, meta, false)
+}
+
+// ActionTime is when a front-desk action takes effect. The zero value means
+// now; anything else is a staff override. A date-only override is taken to
+// happen at the property's standard time for the action, and a Floating one
+// is a wall-clock time in the hotel's timezone whatever zone At carries.
+type ActionTime struct {
+	At       time.Time
+	DateOnly bool
+	Floating bool
+}
+
+func (t ActionTime) override() bool {
+	return !t.At.IsZero()
 }
 
-func (s *Service) CheckIn(ctx context.Context, bookingID string, actionTime time.Time) (*bookingdomain.Booking, error) {
+// CheckIn marks the guest of a confirmed booking as checked in, assigning a
+// room first if the booking was made by room type. Room nights are posted to the folio by the
+// night audit. Arriving before the standard check-in time needs the room to
+// be vacated and is charged an early check-in fee.
+func (s *Service) CheckIn(ctx context.Context, bookingID string, at ActionTime, meta ChangeMeta) (*bookingdomain.Booking, error) {
 	booking, err := s.bookings.FindByID(ctx, bookingID)
 	if err != nil {
 		return nil, err
@@ -173,22 +321,46 @@ func (s *Service) CheckIn(ctx context.Context, bookingID string, actionTime time
 		return nil, ErrBookingNotFound
 	}
 
-	if actionTime.IsZero() {
-		actionTime = s.nowFn()
+	if booking.Status != bookingdomain.StatusConfirmed {
+		return nil, ErrNotConfirmed
 	}
-
-	if beforeDate(actionTime, booking.CheckIn) {
+	actionTime := s.resolveActionTime(at, s.cfg.Stay.CheckInTime)
+	if s.actionDate(at, actionTime).Before(booking.CheckIn) {
 		return nil, ErrTooEarlyCheckIn
 	}
 
-	booking.Status = "Checked-in"
-	if err := s.bookings.Update(ctx, *booking); err != nil {
+	before := *booking
+	if booking.RoomID == "" {
+		if s.cfg.Assignment == AssignManual {
+			return nil, ErrRoomNotAssigned
+		}
+		if err := s.assign(ctx, booking, ""); err != nil {
+			return nil, err
+		}
+	}
+	if actionTime.Before(s.atTimeOfDay(booking.CheckIn, s.cfg.Stay.CheckInTime)) {
+		if err := s.ensureRoomVacated(ctx, *booking); err != nil {
+			return nil, err
+		}
+		if err := s.chargeFee(ctx, booking, bookingdomain.FeeEarlyCheckIn, s.cfg.Stay.EarlyCheckInFee, actionTime); err != nil {
+			return nil, err
+		}
+	}
+	booking.Status = bookingdomain.StatusCheckedIn
+	if err := s.bookings.UpdateIfStatus(ctx, *booking, bookingdomain.StatusConfirmed); err != nil {
+		return nil, err
+	}
+	if err := s.record(ctx, bookingdomain.ActionCheckedIn, &before, *booking, meta, at.override()); err != nil {
 		return nil, err
 	}
 	return booking, nil
 }
 
-func (s *Service) CheckOut(ctx context.Context, bookingID string, actionTime time.Time) (*bookingdomain.Booking, error) {
+// CheckOut marks a checked-in guest as checked out. Leaving after the
+// standard check-out time on the departure day is charged a late check-out
+// fee unless one was already granted. The stay's authorization is captured
+// against the folio, which must then be settled unless staff override.
+func (s *Service) CheckOut(ctx context.Context, bookingID string, at ActionTime, meta ChangeMeta) (*bookingdomain.Booking, error) {
 	booking, err := s.bookings.FindByID(ctx, bookingID)
 	if err != nil {
 		return nil, err
@@ -197,35 +369,65 @@ func (s *Service) CheckOut(ctx context.Context, bookingID string, actionTime tim
 		return nil, ErrBookingNotFound
 	}
 
-	if actionTime.IsZero() {
-		actionTime = s.nowFn()
+	if booking.Status != bookingdomain.StatusCheckedIn {
+		return nil, ErrNotCheckedIn
 	}
-
-	if beforeDate(actionTime, booking.CheckOut) {
+	actionTime := s.resolveActionTime(at, s.cfg.Stay.CheckOutTime)
+	if s.actionDate(at, actionTime).Before(booking.CheckOut) {
 		return nil, ErrTooEarlyCheckOut
 	}
 
-	booking.Status = "Checked-out"
+	before := *booking
+	if s.leftLate(*booking, actionTime) && !booking.HasFee(bookingdomain.FeeLateCheckOut) {
+		if err := s.chargeFee(ctx, booking, bookingdomain.FeeLateCheckOut, s.cfg.Stay.LateCheckOutFee, actionTime); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.postRoomCharges(ctx, booking, meta.Actor); err != nil {
+		return nil, err
+	}
+	if err := checkBalance(outstandingAfterCapture(*booking), meta); err != nil {
+		return nil, err
+	}
+
+	// The guest is claimed as checked out before the payment is settled, so
+	// a second check-out cannot settle it again.
+	booking.Status = bookingdomain.StatusCheckedOut
+	booking.DepartureOverdue = false
+	if err := s.bookings.UpdateIfStatus(ctx, *booking, bookingdomain.StatusCheckedIn); err != nil {
+		return nil, err
+	}
+	if err := s.settlePayment(ctx, booking, booking.Balance(), meta.Actor); err != nil {
+		_ = s.bookings.Update(ctx, before)
+		return nil, err
+	}
 	if err := s.bookings.Update(ctx, *booking); err != nil {
 		return nil, err
 	}
+	if err := s.record(ctx, bookingdomain.ActionCheckedOut, &before, *booking, meta, at.override()); err != nil {
+		return nil, err
+	}
 	return booking, nil
 }
 
-func overlaps(startA, endA, startB, endB time.Time) bool {
-	return startA.Before(endB) && endA.After(startB)
+// today is the property's business date.
+func (s *Service) today() propertydomain.Date {
+	return s.clock.BusinessDate()
 }
 
-func startOfDay(t time.Time) time.Time {
-	y, m, d := t.Date()
-	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+// localDate is the property's calendar date at instant t.
+func (s *Service) localDate(t time.Time) propertydomain.Date {
+	return propertydomain.DateIn(t, s.cfg.Property.Location())
 }
 
-func endOfDay(t time.Time) time.Time {
-	y, m, d := t.Date()
-	return time.Date(y, m, d, 23, 59, 59, int(time.Second-time.Nanosecond), t.Location())
+// atTimeOfDay returns the instant on stay date d when the hotel's clocks
+// show the given offset from midnight.
+func (s *Service) atTimeOfDay(d propertydomain.Date, offset time.Duration) time.Time {
+	return d.At(offset, s.cfg.Property.Location())
 }
 
-func beforeDate(a, b time.Time) bool {
-	return startOfDay(a).Before(startOfDay(b))
+// arrivalPassed reports whether the booking's arrival date is already
+// behind the hotel's calendar.
+func (s *Service) arrivalPassed(b bookingdomain.Booking) bool {
+	return b.CheckIn.Before(s.today())
 }
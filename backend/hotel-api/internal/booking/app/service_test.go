@@ -0,0 +1,46 @@
+package app
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
+)
+
+func TestAtTimeOfDayAcrossDSTChanges(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &Service{cfg: Config{Property: propertydomain.Property{Timezone: newYork}}}
+
+	tests := []struct {
+		name   string
+		date   string
+		offset time.Duration
+		want   string
+	}{
+		{"day before spring forward", "2025-03-08", 15 * time.Hour, "2025-03-08T20:00:00Z"},
+		{"spring forward check-out", "2025-03-09", 11 * time.Hour, "2025-03-09T15:00:00Z"},
+		{"spring forward check-in", "2025-03-09", 15 * time.Hour, "2025-03-09T19:00:00Z"},
+		{"fall back check-out", "2025-11-02", 11 * time.Hour, "2025-11-02T16:00:00Z"},
+		{"fall back check-in", "2025-11-02", 15 * time.Hour, "2025-11-02T20:00:00Z"},
+		{"day after fall back", "2025-11-03", 11 * time.Hour, "2025-11-03T16:00:00Z"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := propertydomain.ParseDate(tt.date)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := s.atTimeOfDay(d, tt.offset).UTC().Format(time.RFC3339)
+			if got != tt.want {
+				t.Errorf("atTimeOfDay(%s, %s) = %s, want %s", tt.date, tt.offset, got, tt.want)
+			}
+			if local := s.localDate(s.atTimeOfDay(d, tt.offset)); local.String() != tt.date {
+				t.Errorf("atTimeOfDay(%s, %s) falls on %s locally", tt.date, tt.offset, local)
+			}
+		})
+	}
+}
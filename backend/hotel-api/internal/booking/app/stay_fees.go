@@ -0,0 +1,198 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	bookingdomain "github.com/yourorg/hotel-api/internal/booking/domain"
+	propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
+)
+
+var (
+	ErrEarlyCheckInUnavailable    = errors.New("room is not yet vacated for early check-in")
+	ErrLateCheckOutUnavailable    = errors.New("room is needed for another arrival; late check-out unavailable")
+	ErrLateCheckOutNotInHouse     = errors.New("late check-out can only be granted to checked-in guests")
+	ErrFeeNotFound                = errors.New("fee not found")
+	ErrFeeAlreadyWaived           = errors.New("fee already waived")
+	ErrWaiveReasonRequired        = errors.New("a reason is required to waive a fee")
+	ErrLateCheckOutAlreadyGranted = errors.New("late check-out already granted")
+)
+
+// GrantLateCheckOut agrees a departure after the standard check-out time for
+// an in-house guest. It is only possible when nobody else arrives in the room
+// on the departure date, and charges the late check-out fee.
+func (s *Service) GrantLateCheckOut(ctx context.Context, bookingID string, meta ChangeMeta) (*bookingdomain.Booking, error) {
+	booking, err := s.bookings.FindByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if booking == nil {
+		return nil, ErrBookingNotFound
+	}
+	if booking.Status != bookingdomain.StatusCheckedIn {
+		return nil, ErrLateCheckOutNotInHouse
+	}
+	if booking.HasFee(bookingdomain.FeeLateCheckOut) {
+		return nil, ErrLateCheckOutAlreadyGranted
+	}
+
+	all, err := s.bookings.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range all {
+		if b.ID == booking.ID || !b.BlocksInventory() {
+			continue
+		}
+		for _, seg := range b.Stays() {
+			if seg.RoomID == booking.RoomID && seg.From == booking.CheckOut {
+				return nil, ErrLateCheckOutUnavailable
+			}
+		}
+	}
+
+	before := *booking
+	if err := s.chargeFee(ctx, booking, bookingdomain.FeeLateCheckOut, s.cfg.Stay.LateCheckOutFee, s.clock.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.bookings.Update(ctx, *booking); err != nil {
+		return nil, err
+	}
+	if err := s.record(ctx, bookingdomain.ActionLateCheckOutGranted, &before, *booking, meta, false); err != nil {
+		return nil, err
+	}
+	return booking, nil
+}
+
+// WaiveFee cancels a fee on the booking. The reason is mandatory and kept
+// with the fee.
+func (s *Service) WaiveFee(ctx context.Context, bookingID, feeID string, meta ChangeMeta) (*bookingdomain.Booking, error) {
+	if strings.TrimSpace(meta.Reason) == "" {
+		return nil, ErrWaiveReasonRequired
+	}
+
+	booking, err := s.bookings.FindByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if booking == nil {
+		return nil, ErrBookingNotFound
+	}
+
+	before := *booking
+	fees := append([]bookingdomain.Fee(nil), booking.Fees...)
+	idx := -1
+	for i, f := range fees {
+		if f.ID == feeID {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return nil, ErrFeeNotFound
+	}
+	if fees[idx].Waived {
+		return nil, ErrFeeAlreadyWaived
+	}
+
+	fees[idx].Waived = true
+	fees[idx].WaivedBy = meta.Actor
+	fees[idx].WaiveReason = strings.TrimSpace(meta.Reason)
+	booking.Fees = fees
+
+	if err := s.bookings.Update(ctx, *booking); err != nil {
+		return nil, err
+	}
+	if err := s.record(ctx, bookingdomain.ActionFeeWaived, &before, *booking, meta, false); err != nil {
+		return nil, err
+	}
+	return booking, nil
+}
+
+// ensureRoomVacated checks that no other guest is still due to leave the
+// room on the booking's arrival date.
+func (s *Service) ensureRoomVacated(ctx context.Context, booking bookingdomain.Booking) error {
+	all, err := s.bookings.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, b := range all {
+		if b.ID == booking.ID || !b.BlocksInventory() || b.Status == bookingdomain.StatusCheckedOut {
+			continue
+		}
+		for _, seg := range b.Stays() {
+			if seg.RoomID == booking.RoomID && seg.To == booking.CheckIn {
+				return ErrEarlyCheckInUnavailable
+			}
+		}
+	}
+	return nil
+}
+
+// chargeFee adds a fee worth nights times the room's rate to the booking,
+// priced at the first night for early check-in and the last night
+// otherwise. Nothing is recorded when the fee works out to zero.
+func (s *Service) chargeFee(ctx context.Context, booking *bookingdomain.Booking, kind string, nights float64, at time.Time) error {
+	if nights <= 0 {
+		return nil
+	}
+	night := booking.CheckOut.AddDays(-1)
+	if kind == bookingdomain.FeeEarlyCheckIn {
+		night = booking.CheckIn
+	}
+	rate, err := s.rateOn(ctx, *booking, night)
+	if err != nil {
+		return err
+	}
+
+	amount := math.Round(rate*nights*100) / 100
+	if amount <= 0 {
+		return nil
+	}
+	booking.Fees = append(append([]bookingdomain.Fee(nil), booking.Fees...), bookingdomain.Fee{
+		ID:        newID("fee"),
+		Kind:      kind,
+		Amount:    amount,
+		ChargedAt: at,
+	})
+	return nil
+}
+
+// leftLate reports whether a check-out at the given time is a late
+// departure: after the standard check-out time on the departure day itself.
+// A check-out recorded on a later day is staff catching up, not a guest who
+// stayed on, and is not charged automatically.
+func (s *Service) leftLate(b bookingdomain.Booking, at time.Time) bool {
+	if s.localDate(at) != b.CheckOut {
+		return false
+	}
+	return at.After(s.atTimeOfDay(b.CheckOut, s.cfg.Stay.CheckOutTime))
+}
+
+// actionDate is the hotel day an action belongs to: the business date unless
+// staff overrode when it happened.
+func (s *Service) actionDate(at ActionTime, actionTime time.Time) propertydomain.Date {
+	if !at.override() {
+		return s.today()
+	}
+	return s.localDate(actionTime)
+}
+
+// resolveActionTime turns an action override into the instant it takes
+// effect, defaulting to now.
+func (s *Service) resolveActionTime(at ActionTime, standard time.Duration) time.Time {
+	if !at.override() {
+		return s.clock.Now()
+	}
+	if at.DateOnly {
+		return s.atTimeOfDay(propertydomain.DateOf(at.At), standard)
+	}
+	if at.Floating {
+		day := propertydomain.DateOf(at.At)
+		return s.atTimeOfDay(day, at.At.Sub(day.Time()))
+	}
+	return at.At
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"time"
+
+	propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
+)
+
+// AuditReport is the end-of-day report the night audit stores for each
+// business date it closes.
+type AuditReport struct {
+	Date       propertydomain.Date
+	PropertyID string
+	RunAt      time.Time
+	RunBy      string
+
+	RoomsAvailable int
+	RoomsOccupied  int
+	// Occupancy is the share of available rooms occupied, in percent.
+	Occupancy  float64
+	Arrivals   int
+	Departures int
+	// RoomNightsPosted counts the room charges this audit posted.
+	RoomNightsPosted    int
+	NoShows             []string
+	UncheckedDepartures []string
+
+	RoomRevenue  float64
+	OtherRevenue float64
+	TaxCollected float64
+	Payments     float64
+	// ADR is room revenue per occupied room, RevPAR per available room.
+	ADR    float64
+	RevPAR float64
+}
+
+// Totals derives the ratios from the counted rooms and revenue.
+func (r *AuditReport) Totals() {
+	r.RoomRevenue = RoundAmount(r.RoomRevenue)
+	r.OtherRevenue = RoundAmount(r.OtherRevenue)
+	r.TaxCollected = RoundAmount(r.TaxCollected)
+	r.Payments = RoundAmount(r.Payments)
+	r.Occupancy, r.ADR, r.RevPAR = 0, 0, 0
+	if r.RoomsAvailable > 0 {
+		r.Occupancy = RoundAmount(float64(r.RoomsOccupied) * 100 / float64(r.RoomsAvailable))
+		r.RevPAR = RoundAmount(r.RoomRevenue / float64(r.RoomsAvailable))
+	}
+	if r.RoomsOccupied > 0 {
+		r.ADR = RoundAmount(r.RoomRevenue / float64(r.RoomsOccupied))
+	}
+}
@@ -1,14 +1,166 @@
 package domain
 
-import "time"
+import (
+	"time"
 
-// Booking represents a reservation made by a user for a room.
+	propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
+)
+
+// Booking statuses as stored on Booking.Status.
+const (
+	StatusConfirmed  = "confirmed"
+	StatusCancelled  = "cancelled"
+	StatusCheckedIn  = "Checked-in"
+	StatusCheckedOut = "Checked-out"
+	StatusNoShow     = "no-show"
+)
+
+// Booking represents a reservation made by a user for a room. Bookings may
+// be made for a room type only; RoomID stays empty until a room is assigned.
 type Booking struct {
+	ID               string
+	ConfirmationCode string
+	UserID           string
+	RoomID           string
+	RoomType         string
+	Segments         []Segment
+	Guests           int
+	// ChildAges gives the age of each child among the guests.
+	ChildAges       []int
+	CheckIn         propertydomain.Date
+	CheckOut        propertydomain.Date
+	Status          string
+	NoShowFee       float64
+	SpecialRequests string
+	StaffNotes      []StaffNote
+	Fees            []Fee
+	// Rates are the nightly room rates agreed when the stay was booked.
+	Rates []NightRate
+	// RatePlan is the plan the stay was sold under, as it stood at booking,
+	// or nil for stays booked at the plain calendar rate.
+	RatePlan *BookedPlan
+	// Promo is the promo code redeemed on the booking, if any.
+	Promo   *AppliedPromo
+	Folio   []FolioLine
+	Payment PaymentState
+	// Schedule lists the prepayments due before arrival, in due order.
+	Schedule []Installment
+	// DepositOverdue is set once a scheduled payment was missed.
+	DepositOverdue bool
+	// DepartureOverdue is set by the night audit when a guest is still
+	// checked in after their departure date.
+	DepartureOverdue bool
+	CreatedAt        time.Time
+}
+
+// BookedPlan is the rate plan a stay was sold under, copied at booking so
+// later changes to the plan leave the booking's terms alone.
+type BookedPlan struct {
+	ID           string
+	Name         string
+	Inclusions   []string
+	Cancellation CancellationTerms
+}
+
+// CancellationTerms say what cancelling costs. Non-refundable stays keep
+// everything when cancelled; otherwise a booking is free to cancel until
+// FreeCancellation before the standard check-in time on arrival, and costs
+// FeeNights nights after that.
+type CancellationTerms struct {
+	NonRefundable    bool
+	FreeCancellation time.Duration
+	FeeNights        float64
+}
+
+// Fee kinds charged against a booking.
+const (
+	FeeEarlyCheckIn = "early_check_in"
+	FeeLateCheckOut = "late_check_out"
+	FeeCancellation = "cancellation"
+)
+
+// Fee is a surcharge recorded against a booking. Waived fees stay on the
+// booking for the record but are not owed.
+type Fee struct {
+	ID          string
+	Kind        string
+	Amount      float64
+	ChargedAt   time.Time
+	Waived      bool
+	WaivedBy    string
+	WaiveReason string
+}
+
+// NightRate is the room rate agreed for one night of the stay and the
+// promo discount taken off it.
+type NightRate struct {
+	Night    propertydomain.Date
+	Amount   float64
+	Discount float64
+}
+
+// AppliedPromo is a promo code redeemed on a booking and the discount it
+// gave across the stay.
+type AppliedPromo struct {
+	Code     string
+	Discount float64
+}
+
+// RateFor returns the agreed rate for the night, if one was recorded.
+func (b Booking) RateFor(night propertydomain.Date) (float64, bool) {
+	for _, r := range b.Rates {
+		if r.Night == night {
+			return r.Amount, true
+		}
+	}
+	return 0, false
+}
+
+// DiscountFor returns the promo discount agreed for the night.
+func (b Booking) DiscountFor(night propertydomain.Date) float64 {
+	for _, r := range b.Rates {
+		if r.Night == night {
+			return r.Discount
+		}
+	}
+	return 0
+}
+
+// StaffNote is an internal comment on a booking, visible to admins only.
+type StaffNote struct {
 	ID        string
-	UserID    string
-	RoomID    string
-	CheckIn   time.Time
-	CheckOut  time.Time
-	Status    string
+	Author    string
+	Text      string
 	CreatedAt time.Time
 }
+
+// HasFee reports whether a fee of the given kind was already charged.
+func (b Booking) HasFee(kind string) bool {
+	for _, f := range b.Fees {
+		if f.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// BlocksInventory reports whether the booking still holds its room for the
+// stay. Cancelled bookings and no-shows release their nights.
+func (b Booking) BlocksInventory() bool {
+	switch b.Status {
+	case StatusCancelled, StatusNoShow:
+		return false
+	default:
+		return true
+	}
+}
+
+// Modifiable reports whether the guest may still change the booking.
+func (b Booking) Modifiable() bool {
+	switch b.Status {
+	case StatusCancelled, StatusNoShow, StatusCheckedOut:
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// DepositRule asks for part of the stay to be paid up front. The deposit is
+// either Percent of the stay total or the first Nights nights.
+type DepositRule struct {
+	// RoomType limits the rule to one type; empty applies to every type.
+	RoomType string
+	// MinNights limits the rule to stays of at least this many nights.
+	MinNights int
+	Percent   float64
+	Nights    int
+	// DueDays is how many days after booking the deposit is due; zero
+	// takes it at booking time.
+	DueDays int
+	// BalanceDueDays, when set, asks for the rest of the stay this many
+	// days before arrival.
+	BalanceDueDays int
+}
+
+// Applies reports whether the rule covers a stay of the type and length.
+func (r DepositRule) Applies(roomType string, nights int) bool {
+	if r.RoomType != "" && !strings.EqualFold(r.RoomType, roomType) {
+		return false
+	}
+	return nights >= r.MinNights
+}
+
+// MatchDepositRule picks the most specific rule for the stay: type-specific
+// rules before general ones, then the longest minimum stay.
+func MatchDepositRule(rules []DepositRule, roomType string, nights int) (DepositRule, bool) {
+	var best DepositRule
+	found := false
+	for _, r := range rules {
+		if !r.Applies(roomType, nights) {
+			continue
+		}
+		if !found || moreSpecific(r, best) {
+			best, found = r, true
+		}
+	}
+	return best, found
+}
+
+func moreSpecific(a, b DepositRule) bool {
+	if (a.RoomType != "") != (b.RoomType != "") {
+		return a.RoomType != ""
+	}
+	return a.MinNights > b.MinNights
+}
+
+// Installment is an amount of the stay due by a date.
+type Installment struct {
+	ID          string
+	Description string
+	Amount      float64
+	DueAt       time.Time
+}
+
+// InstallmentOutstanding returns what is still unpaid of the installment.
+// Payments settle installments in due order.
+func (b Booking) InstallmentOutstanding(id string) float64 {
+	paid := b.Paid()
+	for _, in := range b.Schedule {
+		covered := paid
+		if covered > in.Amount {
+			covered = in.Amount
+		}
+		if covered < 0 {
+			covered = 0
+		}
+		paid -= covered
+		if in.ID == id {
+			return RoundAmount(in.Amount - covered)
+		}
+	}
+	return 0
+}
+
+// AmountDue is what the schedule asks to have been paid by at and is not.
+func (b Booking) AmountDue(at time.Time) float64 {
+	var due float64
+	for _, in := range b.Schedule {
+		if !in.DueAt.After(at) {
+			due += b.InstallmentOutstanding(in.ID)
+		}
+	}
+	return RoundAmount(due)
+}
+
+// NextInstallment returns the earliest installment not yet fully paid.
+func (b Booking) NextInstallment() (Installment, bool) {
+	for _, in := range b.Schedule {
+		if b.InstallmentOutstanding(in.ID) > 0 {
+			return in, true
+		}
+	}
+	return Installment{}, false
+}
@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
+)
+
+// Folio line kinds. Room charges, ad-hoc charges, fees and taxes add to what
+// the guest owes; discounts and payments reduce it and refunds add it back.
+const (
+	FolioRoom     = "room"
+	FolioDiscount = "discount"
+	FolioCharge   = "charge"
+	FolioFee      = "fee"
+	FolioTax      = "tax"
+	FolioPayment  = "payment"
+	FolioRefund   = "refund"
+)
+
+// FolioLine is one posting on a booking's folio. Amounts are always
+// positive; the kind decides whether the line is owed or paid.
+type FolioLine struct {
+	ID          string
+	Kind        string
+	Description string
+	Amount      float64
+	// Night is the stay night a room charge covers.
+	Night propertydomain.Date
+	// TaxFor is the ID of the line a tax line was charged on.
+	TaxFor string
+	// AuthorizationID is the card authorization a payment or refund line
+	// moved money on.
+	AuthorizationID string
+	PostedBy        string
+	PostedAt        time.Time
+}
+
+// Signed returns the line's effect on the balance.
+func (l FolioLine) Signed() float64 {
+	switch l.Kind {
+	case FolioPayment, FolioDiscount:
+		return -l.Amount
+	}
+	return l.Amount
+}
+
+// FolioLines returns the posted lines together with the booking's unwaived
+// fees, oldest first.
+func (b Booking) FolioLines() []FolioLine {
+	lines := append([]FolioLine(nil), b.Folio...)
+	for _, f := range b.Fees {
+		if f.Waived {
+			continue
+		}
+		lines = append(lines, FolioLine{
+			ID:          f.ID,
+			Kind:        FolioFee,
+			Description: f.Kind,
+			Amount:      f.Amount,
+			PostedBy:    "system",
+			PostedAt:    f.ChargedAt,
+		})
+	}
+	if b.NoShowFee > 0 {
+		lines = append(lines, FolioLine{
+			ID:          b.ID + "-no-show",
+			Kind:        FolioFee,
+			Description: "no_show",
+			Amount:      b.NoShowFee,
+			PostedBy:    "system",
+			PostedAt:    b.CheckIn.Time(),
+		})
+	}
+	sort.SliceStable(lines, func(i, j int) bool { return lines[i].PostedAt.Before(lines[j].PostedAt) })
+	return lines
+}
+
+// Balance is what the guest still owes; negative means in credit.
+func (b Booking) Balance() float64 {
+	var total float64
+	for _, l := range b.FolioLines() {
+		total += l.Signed()
+	}
+	return RoundAmount(total)
+}
+
+// Paid is the total of payments less refunds.
+func (b Booking) Paid() float64 {
+	var total float64
+	for _, l := range b.Folio {
+		switch l.Kind {
+		case FolioPayment:
+			total += l.Amount
+		case FolioRefund:
+			total -= l.Amount
+		}
+	}
+	return RoundAmount(total)
+}
+
+// RoomCharged reports whether the room charge for the night was posted.
+func (b Booking) RoomCharged(night propertydomain.Date) bool {
+	for _, l := range b.Folio {
+		if l.Kind == FolioRoom && l.Night == night {
+			return true
+		}
+	}
+	return false
+}
+
+// RoundAmount rounds a money amount to cents.
+func RoundAmount(v float64) float64 {
+	return math.Round(v*100) / 100
+}
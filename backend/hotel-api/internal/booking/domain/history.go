@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Actions recorded in a booking's history.
+const (
+	ActionCreated                = "created"
+	ActionCancelled              = "cancelled"
+	ActionCheckedIn              = "checked_in"
+	ActionCheckedOut             = "checked_out"
+	ActionNoShow                 = "no_show"
+	ActionSpecialRequestsUpdated = "special_requests_updated"
+	ActionStaffNoteAdded         = "staff_note_added"
+	ActionStaffNoteDeleted       = "staff_note_deleted"
+	ActionLateCheckOutGranted    = "late_check_out_granted"
+	ActionFeeWaived              = "fee_waived"
+	ActionRoomAssigned           = "room_assigned"
+	ActionRoomMoved              = "room_moved"
+	ActionFolioPosted            = "folio_posted"
+	ActionDepositOverdue         = "deposit_overdue"
+	ActionDepartureOverdue       = "departure_overdue"
+)
+
+// HistoryEntry records one mutation of a booking.
+type HistoryEntry struct {
+	ID        string
+	BookingID string
+	Actor     string
+	Action    string
+	Reason    string
+	// Override is set when staff forced the action, for example by
+	// back-dating a check-in with an explicit action date.
+	Override bool
+	At       time.Time
+	Changes  []FieldChange
+}
+
+// FieldChange is a single field's value before and after a mutation.
+type FieldChange struct {
+	Field  string
+	Before string
+	After  string
+}
+
+// Snapshot flattens the booking into comparable field values.
+func (b Booking) Snapshot() map[string]string {
+	notes := make([]string, 0, len(b.StaffNotes))
+	for _, n := range b.StaffNotes {
+		notes = append(notes, n.ID)
+	}
+	segments := make([]string, 0, len(b.Segments))
+	for _, seg := range b.Segments {
+		segments = append(segments, seg.RoomID+"@"+seg.From.String()+".."+seg.To.String())
+	}
+	fees := make([]string, 0, len(b.Fees))
+	for _, f := range b.Fees {
+		fee := f.Kind + "=" + formatAmount(f.Amount)
+		if f.Waived {
+			fee += " (waived)"
+		}
+		fees = append(fees, fee)
+	}
+	folio := make([]string, 0, len(b.Folio))
+	for _, l := range b.Folio {
+		folio = append(folio, l.Kind+"="+formatAmount(l.Amount))
+	}
+	schedule := make([]string, 0, len(b.Schedule))
+	for _, in := range b.Schedule {
+		schedule = append(schedule, formatAmount(in.Amount)+"@"+formatDate(in.DueAt))
+	}
+	payment := ""
+	if b.Payment.AuthorizationID != "" {
+		payment = b.Payment.Status + " " + strconv.FormatFloat(b.Payment.Authorized, 'f', 2, 64) +
+			" captured " + strconv.FormatFloat(b.Payment.Captured, 'f', 2, 64) +
+			" refunded " + strconv.FormatFloat(b.Payment.Refunded, 'f', 2, 64)
+	}
+	return map[string]string{
+		"confirmationCode": b.ConfirmationCode,
+		"userId":           b.UserID,
+		"roomId":           b.RoomID,
+		"roomType":         b.RoomType,
+		"segments":         strings.Join(segments, ","),
+		"guests":           strconv.Itoa(b.Guests),
+		"checkIn":          b.CheckIn.String(),
+		"checkOut":         b.CheckOut.String(),
+		"status":           b.Status,
+		"noShowFee":        formatAmount(b.NoShowFee),
+		"specialRequests":  b.SpecialRequests,
+		"staffNotes":       strings.Join(notes, ","),
+		"fees":             strings.Join(fees, ","),
+		"folio":            strings.Join(folio, ","),
+		"payment":          payment,
+		"schedule":         strings.Join(schedule, ","),
+		"depositOverdue":   strconv.FormatBool(b.DepositOverdue),
+		"departureOverdue": strconv.FormatBool(b.DepartureOverdue),
+	}
+}
+
+// Diff lists the fields that differ between two versions of a booking,
+// sorted by field name. A nil before means the booking was just created.
+func Diff(before *Booking, after Booking) []FieldChange {
+	var prev map[string]string
+	if before != nil {
+		prev = before.Snapshot()
+	}
+	next := after.Snapshot()
+
+	var changes []FieldChange
+	for field, value := range next {
+		if prev[field] != value {
+			changes = append(changes, FieldChange{Field: field, Before: prev[field], After: value})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes
+}
+
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+func formatAmount(v float64) string {
+	if v == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"strings"
+
+	propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
+)
+
+// Inventory answers availability questions over a snapshot of rooms and
+// bookings. Availability is counted per night: a booking occupies the nights
+// from its check-in date up to, but not including, its check-out date.
+type Inventory struct {
+	rooms       map[string]RoomUnit
+	bookings    []Booking
+	overbooking OverbookingPolicy
+}
+
+// RoomUnit is what the inventory needs to know about a physical room.
+type RoomUnit struct {
+	ID       string
+	Type     string
+	Sellable bool
+}
+
+func NewInventory(rooms []RoomUnit, bookings []Booking) Inventory {
+	byID := make(map[string]RoomUnit, len(rooms))
+	for _, r := range rooms {
+		byID[r.ID] = r
+	}
+	return Inventory{rooms: byID, bookings: bookings}
+}
+
+// WithOverbooking returns a copy of the inventory that lets room types be
+// sold beyond their physical rooms as the policy allows.
+func (inv Inventory) WithOverbooking(p OverbookingPolicy) Inventory {
+	inv.overbooking = p
+	return inv
+}
+
+// RoomTypeOf returns the room type a booking draws from.
+func (inv Inventory) RoomTypeOf(b Booking) string {
+	if b.RoomType != "" {
+		return b.RoomType
+	}
+	return inv.rooms[b.RoomID].Type
+}
+
+// roomTypeOn returns the room type a booking draws from on one night, which
+// for split stays depends on the segment's room.
+func (inv Inventory) roomTypeOn(b Booking, night propertydomain.Date) string {
+	if roomID := b.RoomOn(night); roomID != "" {
+		if r, ok := inv.rooms[roomID]; ok {
+			return r.Type
+		}
+	}
+	return b.RoomType
+}
+
+// RoomFree reports whether no other blocking booking occupies the room on
+// any night of the stay. Each segment of a split stay counts on its own.
+func (inv Inventory) RoomFree(roomID string, from, to propertydomain.Date, excludeBookingID string) bool {
+	for _, b := range inv.bookings {
+		if b.ID == excludeBookingID || !b.BlocksInventory() {
+			continue
+		}
+		for _, seg := range b.Stays() {
+			if seg.RoomID == roomID && NightsOverlap(from, to, seg.From, seg.To) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// TypeAvailability returns the fewest rooms of the type left to sell on any
+// night of the stay, including the overbooking allowance. Assigned and
+// unassigned bookings of the type both count.
+func (inv Inventory) TypeAvailability(roomType string, from, to propertydomain.Date, excludeBookingID string) int {
+	total := inv.typeRooms(roomType)
+	lowest := total + inv.overbooking.Allowance(roomType, from)
+	for _, night := range Nights(from, to) {
+		left := total + inv.overbooking.Allowance(roomType, night) - inv.soldOn(roomType, night, excludeBookingID)
+		if left < lowest {
+			lowest = left
+		}
+	}
+	return lowest
+}
+
+// Oversold lists, per night and room type, where more rooms are sold than
+// physically exist.
+func (inv Inventory) Oversold(from, to propertydomain.Date) []OversoldNight {
+	types := map[string]bool{}
+	for _, r := range inv.rooms {
+		types[r.Type] = true
+	}
+
+	var result []OversoldNight
+	for _, night := range Nights(from, to) {
+		for roomType := range types {
+			total := inv.typeRooms(roomType)
+			sold := inv.soldOn(roomType, night, "")
+			if sold > total {
+				result = append(result, OversoldNight{Date: night, RoomType: roomType, Rooms: total, Sold: sold})
+			}
+		}
+	}
+	return result
+}
+
+func (inv Inventory) typeRooms(roomType string) int {
+	total := 0
+	for _, r := range inv.rooms {
+		if strings.EqualFold(r.Type, roomType) && r.Sellable {
+			total++
+		}
+	}
+	return total
+}
+
+func (inv Inventory) soldOn(roomType string, night propertydomain.Date, excludeBookingID string) int {
+	sold := 0
+	for _, b := range inv.bookings {
+		if b.ID == excludeBookingID || !b.BlocksInventory() {
+			continue
+		}
+		if occupiesNight(b, night) && strings.EqualFold(inv.roomTypeOn(b, night), roomType) {
+			sold++
+		}
+	}
+	return sold
+}
+
+// Nights lists the calendar dates slept between check-in and check-out.
+func Nights(checkIn, checkOut propertydomain.Date) []propertydomain.Date {
+	var nights []propertydomain.Date
+	for d := checkIn; d.Before(checkOut); d = d.AddDays(1) {
+		nights = append(nights, d)
+	}
+	return nights
+}
+
+// NightsOverlap reports whether two stays share at least one night.
+func NightsOverlap(fromA, toA, fromB, toB propertydomain.Date) bool {
+	return fromA.Before(toB) && toA.After(fromB)
+}
+
+func occupiesNight(b Booking, night propertydomain.Date) bool {
+	return !night.Before(b.CheckIn) && night.Before(b.CheckOut)
+}
@@ -0,0 +1,194 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
+)
+
+// Invoice document kinds. A credit note cancels an earlier invoice; invoices
+// are never edited once issued.
+const (
+	InvoiceKindInvoice    = "invoice"
+	InvoiceKindCreditNote = "credit_note"
+)
+
+// Invoice is an issued billing document for a stay.
+type Invoice struct {
+	ID string
+	// Number is the gap-free document number within the property's series,
+	// such as INV-000042. Sequence is its numeric part.
+	Number     string
+	Sequence   int
+	Kind       string
+	BookingID  string
+	PropertyID string
+	// Corrects is the ID of the invoice a credit note cancels.
+	Corrects string
+	Reason   string
+	IssuedAt time.Time
+	IssuedBy string
+	Property propertydomain.Property
+	BillTo   BillingDetails
+	StayFrom propertydomain.Date
+	StayTo   propertydomain.Date
+	Lines    []InvoiceLine
+	Taxes    []TaxBreakdown
+	Subtotal float64
+	TaxTotal float64
+	Total    float64
+	Paid     float64
+}
+
+// BillingDetails is who the invoice is addressed to.
+type BillingDetails struct {
+	Name  string
+	Email string
+}
+
+// InvoiceLine is one billed item. Amount excludes tax.
+type InvoiceLine struct {
+	Description string
+	Date        propertydomain.Date
+	Quantity    int
+	UnitPrice   float64
+	Amount      float64
+	TaxRate     float64
+	Tax         float64
+}
+
+// TaxBreakdown totals the tax charged at one rate.
+type TaxBreakdown struct {
+	Rate    float64
+	Taxable float64
+	Tax     float64
+}
+
+// InvoiceLines turns the booking's folio into billed items, with each tax
+// line folded into the item it was charged on. Discounts are billed as
+// negative items at the tax rate of the room night they came off, whose tax
+// was charged on the discounted rate.
+func (b Booking) InvoiceLines() []InvoiceLine {
+	taxes := map[string]float64{}
+	discounts := map[propertydomain.Date]float64{}
+	for _, l := range b.Folio {
+		switch l.Kind {
+		case FolioTax:
+			taxes[l.TaxFor] += l.Amount
+		case FolioDiscount:
+			discounts[l.Night] += l.Amount
+		}
+	}
+
+	var lines []InvoiceLine
+	nightRates := map[propertydomain.Date]float64{}
+	for _, l := range b.FolioLines() {
+		switch l.Kind {
+		case FolioRoom, FolioCharge, FolioFee:
+		case FolioDiscount:
+			lines = append(lines, InvoiceLine{
+				Description: l.Description,
+				Date:        l.Night,
+				Quantity:    1,
+				UnitPrice:   -l.Amount,
+				Amount:      -l.Amount,
+			})
+			continue
+		default:
+			continue
+		}
+		date := propertydomain.DateOf(l.PostedAt)
+		if !l.Night.IsZero() {
+			date = l.Night
+		}
+		tax := RoundAmount(taxes[l.ID])
+		line := InvoiceLine{
+			Description: l.Description,
+			Date:        date,
+			Quantity:    1,
+			UnitPrice:   l.Amount,
+			Amount:      l.Amount,
+			Tax:         tax,
+		}
+		taxable := l.Amount
+		if l.Kind == FolioRoom {
+			taxable -= discounts[l.Night]
+		}
+		if taxable != 0 {
+			line.TaxRate = math.Round(tax/taxable*10000) / 10000
+		}
+		if l.Kind == FolioRoom {
+			nightRates[l.Night] = line.TaxRate
+		}
+		lines = append(lines, line)
+	}
+	for i, l := range lines {
+		if l.Amount < 0 {
+			lines[i].TaxRate = nightRates[l.Date]
+		}
+	}
+	return lines
+}
+
+// Totals fills in the subtotal, tax breakdown and total from the lines.
+func (inv *Invoice) Totals() {
+	byRate := map[float64]*TaxBreakdown{}
+	inv.Subtotal, inv.TaxTotal = 0, 0
+	for _, l := range inv.Lines {
+		inv.Subtotal += l.Amount
+		inv.TaxTotal += l.Tax
+		t, ok := byRate[l.TaxRate]
+		if !ok {
+			t = &TaxBreakdown{Rate: l.TaxRate}
+			byRate[l.TaxRate] = t
+		}
+		t.Taxable += l.Amount
+		t.Tax += l.Tax
+	}
+
+	inv.Taxes = inv.Taxes[:0]
+	for _, t := range byRate {
+		t.Taxable, t.Tax = RoundAmount(t.Taxable), RoundAmount(t.Tax)
+		inv.Taxes = append(inv.Taxes, *t)
+	}
+	sort.Slice(inv.Taxes, func(i, j int) bool { return inv.Taxes[i].Rate < inv.Taxes[j].Rate })
+	inv.Subtotal = RoundAmount(inv.Subtotal)
+	inv.TaxTotal = RoundAmount(inv.TaxTotal)
+	inv.Total = RoundAmount(inv.Subtotal + inv.TaxTotal)
+}
+
+// CurrentInvoice returns the latest invoice among a booking's documents that
+// no credit note has cancelled.
+func CurrentInvoice(docs []Invoice) (Invoice, bool) {
+	credited := map[string]bool{}
+	for _, d := range docs {
+		if d.Kind == InvoiceKindCreditNote {
+			credited[d.Corrects] = true
+		}
+	}
+	for i := len(docs) - 1; i >= 0; i-- {
+		if docs[i].Kind == InvoiceKindInvoice && !credited[docs[i].ID] {
+			return docs[i], true
+		}
+	}
+	return Invoice{}, false
+}
+
+// CreditNote returns a document reversing the invoice line by line.
+func (inv Invoice) CreditNote() Invoice {
+	note := inv
+	note.ID, note.Number, note.Sequence = "", "", 0
+	note.Kind = InvoiceKindCreditNote
+	note.Corrects = inv.ID
+	note.Paid = 0
+	note.Lines = make([]InvoiceLine, 0, len(inv.Lines))
+	for _, l := range inv.Lines {
+		l.UnitPrice, l.Amount, l.Tax = -l.UnitPrice, -l.Amount, -l.Tax
+		note.Lines = append(note.Lines, l)
+	}
+	note.Taxes = nil
+	note.Totals()
+	return note
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"strings"
+
+	propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
+)
+
+// OverbookingLimit allows selling Limit rooms beyond physical inventory. An
+// empty RoomType applies to every type and a zero Date to every date; the
+// most specific limit wins.
+type OverbookingLimit struct {
+	RoomType string
+	Date     propertydomain.Date
+	Limit    int
+}
+
+// OverbookingPolicy resolves the overbooking allowance for a type and night.
+type OverbookingPolicy struct {
+	limits []OverbookingLimit
+}
+
+func NewOverbookingPolicy(limits []OverbookingLimit) OverbookingPolicy {
+	return OverbookingPolicy{limits: limits}
+}
+
+// Allowance returns how many rooms of the type may be oversold on the night.
+func (p OverbookingPolicy) Allowance(roomType string, night propertydomain.Date) int {
+	best, bestRank := 0, -1
+	for _, l := range p.limits {
+		rank := 0
+		if l.RoomType != "" {
+			if !strings.EqualFold(l.RoomType, roomType) {
+				continue
+			}
+			rank += 2
+		}
+		if !l.Date.IsZero() {
+			if l.Date != night {
+				continue
+			}
+			rank++
+		}
+		if rank > bestRank {
+			best, bestRank = l.Limit, rank
+		}
+	}
+	return best
+}
+
+// OversoldNight reports a room type sold beyond its physical rooms.
+type OversoldNight struct {
+	Date     propertydomain.Date
+	RoomType string
+	Rooms    int
+	Sold     int
+}
+
+func (o OversoldNight) OversoldBy() int {
+	return o.Sold - o.Rooms
+}
@@ -0,0 +1,25 @@
+package domain
+
+// Payment statuses as stored on PaymentState.Status.
+const (
+	PaymentAuthorized = "authorized"
+	PaymentCaptured   = "captured"
+	PaymentVoided     = "voided"
+	PaymentRefunded   = "refunded"
+)
+
+// PaymentState tracks the gateway authorization taken for a booking. The
+// zero value means no payment was taken.
+type PaymentState struct {
+	AuthorizationID string
+	Status          string
+	Authorized      float64
+	Captured        float64
+	Refunded        float64
+}
+
+// Open reports whether the authorization still holds funds that can be
+// captured or voided.
+func (p PaymentState) Open() bool {
+	return p.AuthorizationID != "" && p.Status == PaymentAuthorized
+}
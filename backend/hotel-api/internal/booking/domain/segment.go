@@ -0,0 +1,57 @@
+package domain
+
+import propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
+
+// Segment is the part of a stay spent in one room, from its From date up to,
+// but not including, its To date.
+type Segment struct {
+	RoomID string
+	From   propertydomain.Date
+	To     propertydomain.Date
+}
+
+// Stays returns the booking's occupancy per room. Bookings that never moved
+// have a single segment covering the whole stay in RoomID.
+func (b Booking) Stays() []Segment {
+	if len(b.Segments) > 0 {
+		return b.Segments
+	}
+	return []Segment{{RoomID: b.RoomID, From: b.CheckIn, To: b.CheckOut}}
+}
+
+// RoomOn returns the room the booking occupies on the given night, empty if
+// none is assigned.
+func (b Booking) RoomOn(night propertydomain.Date) string {
+	for _, seg := range b.Stays() {
+		if !night.Before(seg.From) && night.Before(seg.To) {
+			return seg.RoomID
+		}
+	}
+	return ""
+}
+
+// UsesRoom reports whether any segment of the stay is in the room.
+func (b Booking) UsesRoom(roomID string) bool {
+	for _, seg := range b.Stays() {
+		if seg.RoomID == roomID {
+			return true
+		}
+	}
+	return false
+}
+
+// MoveFrom returns the segments after moving the guest to roomID from the
+// given date until check-out. Earlier nights keep their rooms.
+func (b Booking) MoveFrom(roomID string, from propertydomain.Date) []Segment {
+	var segments []Segment
+	for _, seg := range b.Stays() {
+		if !seg.From.Before(from) {
+			continue
+		}
+		if seg.To.After(from) {
+			seg.To = from
+		}
+		segments = append(segments, seg)
+	}
+	return append(segments, Segment{RoomID: roomID, From: from, To: b.CheckOut})
+}
@@ -0,0 +1,46 @@
+package domain
+
+import propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
+
+// Stages a guest sees their bookings grouped by. Unlike Status, the stage is
+// derived from the stay dates and lifecycle state at a given moment.
+const (
+	StageUpcoming  = "upcoming"
+	StageCurrent   = "current"
+	StagePast      = "past"
+	StageCancelled = "cancelled"
+)
+
+// Stage places the booking in one of the guest-facing stages on the
+// property's calendar date today. A checked-in guest is current until checked
+// out, whatever the dates say; confirmed bookings move from upcoming to
+// current on the arrival date and to past once the departure date is reached.
+func (b Booking) Stage(today propertydomain.Date) string {
+	switch b.Status {
+	case StatusCancelled:
+		return StageCancelled
+	case StatusCheckedIn:
+		return StageCurrent
+	case StatusCheckedOut, StatusNoShow:
+		return StagePast
+	}
+
+	switch {
+	case b.CheckIn.After(today):
+		return StageUpcoming
+	case b.CheckOut.After(today):
+		return StageCurrent
+	default:
+		return StagePast
+	}
+}
+
+// ValidStage reports whether s names one of the guest-facing stages.
+func ValidStage(s string) bool {
+	switch s {
+	case StageUpcoming, StageCurrent, StagePast, StageCancelled:
+		return true
+	default:
+		return false
+	}
+}
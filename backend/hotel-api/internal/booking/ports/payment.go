@@ -0,0 +1,27 @@
+package ports
+
+import (
+	"context"
+	"errors"
+)
+
+// Errors a PaymentGateway reports for rejected operations.
+var (
+	ErrPaymentDeclined      = errors.New("payment declined")
+	ErrAuthorizationUnknown = errors.New("payment authorization not found")
+	ErrPaymentState         = errors.New("operation not allowed in the payment's current state")
+)
+
+// PaymentGateway holds and settles guest payments. An authorization
+// reserves funds; it is then captured, voided, or refunded after capture.
+type PaymentGateway interface {
+	// Authorize reserves amount for the reference and returns the
+	// authorization ID.
+	Authorize(ctx context.Context, reference string, amount float64) (string, error)
+	// Capture takes up to the authorized amount and releases the rest.
+	Capture(ctx context.Context, authorizationID string, amount float64) error
+	// Void releases an authorization that has not been captured.
+	Void(ctx context.Context, authorizationID string) error
+	// Refund returns part or all of a captured amount.
+	Refund(ctx context.Context, authorizationID string, amount float64) error
+}
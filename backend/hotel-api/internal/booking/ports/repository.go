@@ -2,14 +2,130 @@ package ports
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/yourorg/hotel-api/internal/booking/domain"
+	propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
 )
 
+// Sort fields accepted by BookingQuery.Sort.
+const (
+	SortByCheckIn   = "checkIn"
+	SortByCheckOut  = "checkOut"
+	SortByCreatedAt = "createdAt"
+	SortByStatus    = "status"
+	SortByID        = "id"
+)
+
+// BookingQuery filters, orders and pages bookings. Zero-valued fields do not
+// filter.
+type BookingQuery struct {
+	Statuses    []string
+	RoomIDs     []string
+	UserIDs     []string
+	From        *propertydomain.Date // check-in on or after
+	To          *propertydomain.Date // check-out on or before
+	ArrivalOn   *propertydomain.Date
+	DepartureOn *propertydomain.Date
+	Code        string
+	// Text matches the booking ID, confirmation code, special requests and
+	// staff notes, case-insensitively.
+	Text string
+
+	Sort       string
+	Descending bool
+	// After resumes the result after the booking with this key, so pages
+	// neither skip nor repeat bookings added or removed in between.
+	After *BookingKey
+	// Limit caps the page size; zero returns every booking.
+	Limit int
+}
+
+// BookingKey holds the fields bookings are sorted by. Ties on the sort
+// field are broken by ID.
+type BookingKey struct {
+	ID        string
+	CheckIn   propertydomain.Date
+	CheckOut  propertydomain.Date
+	CreatedAt time.Time
+	Status    string
+}
+
+func KeyOf(b domain.Booking) BookingKey {
+	return BookingKey{ID: b.ID, CheckIn: b.CheckIn, CheckOut: b.CheckOut, CreatedAt: b.CreatedAt, Status: b.Status}
+}
+
+// BookingPage is one page of a BookingQuery result.
+type BookingPage struct {
+	Bookings []domain.Booking
+	Total    int
+	// More reports whether further bookings follow this page.
+	More bool
+}
+
+// ErrBookingChanged is returned by UpdateIfStatus when the stored booking
+// no longer has the expected status.
+var ErrBookingChanged = errors.New("booking was changed by another request")
+
 type BookingRepository interface {
 	FindByUser(ctx context.Context, userID string) ([]domain.Booking, error)
 	Create(ctx context.Context, booking domain.Booking) error
 	List(ctx context.Context) ([]domain.Booking, error)
+	Search(ctx context.Context, query BookingQuery) (BookingPage, error)
 	FindByID(ctx context.Context, id string) (*domain.Booking, error)
+	FindByConfirmationCode(ctx context.Context, code string) (*domain.Booking, error)
 	Update(ctx context.Context, booking domain.Booking) error
+	// UpdateIfStatus stores the booking only if the stored copy still has
+	// the given status, so a status change cannot overwrite a concurrent one.
+	UpdateIfStatus(ctx context.Context, booking domain.Booking, status string) error
+}
+
+// HistoryRepository stores the append-only change log of bookings.
+type HistoryRepository interface {
+	AppendHistory(ctx context.Context, entry domain.HistoryEntry) error
+	ListHistory(ctx context.Context, bookingID string) ([]domain.HistoryEntry, error)
+}
+
+// OverbookingRepository stores the overbooking limits set by revenue staff.
+type OverbookingRepository interface {
+	ListOverbookingLimits(ctx context.Context) ([]domain.OverbookingLimit, error)
+	SaveOverbookingLimit(ctx context.Context, limit domain.OverbookingLimit) error
+	DeleteOverbookingLimit(ctx context.Context, roomType string, date propertydomain.Date) error
+}
+
+// ErrInvoiceCredited is returned by IssueInvoice for a credit note on an
+// invoice that another credit note already cancelled.
+var ErrInvoiceCredited = errors.New("invoice was already credited")
+
+// InvoiceRepository stores issued invoices and credit notes.
+type InvoiceRepository interface {
+	// IssueInvoice numbers the document with the next sequence in its
+	// property's series and stores it in one step, so numbers have no gaps.
+	// An invoice is only issued if its booking has no current one, which is
+	// returned instead, so a booking is never invoiced twice.
+	IssueInvoice(ctx context.Context, invoice domain.Invoice) (domain.Invoice, error)
+	// ListInvoices returns a booking's documents in issue order.
+	ListInvoices(ctx context.Context, bookingID string) ([]domain.Invoice, error)
+}
+
+// CalendarTokenRepository stores the secret token in each guest's calendar
+// feed URL.
+type CalendarTokenRepository interface {
+	// SaveCalendarToken stores token as the user's feed token and returns
+	// the token now in effect. Unless replace is set, a token the user
+	// already has is kept and returned instead.
+	SaveCalendarToken(ctx context.Context, userID, token string, replace bool) (string, error)
+	// FindCalendarUser returns the user the token belongs to, or "" if none.
+	FindCalendarUser(ctx context.Context, token string) (string, error)
+}
+
+// AuditRepository stores the end-of-day reports of the night audit, one per
+// business date.
+type AuditRepository interface {
+	SaveAuditReport(ctx context.Context, report domain.AuditReport) error
+	// FindAuditReport returns nil if the date has not been audited.
+	FindAuditReport(ctx context.Context, date propertydomain.Date) (*domain.AuditReport, error)
+	// ListAuditReports returns all reports, oldest date first.
+	ListAuditReports(ctx context.Context) ([]domain.AuditReport, error)
 }
@@ -0,0 +1,120 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	propertyapp "github.com/yourorg/hotel-api/internal/property/app"
+	propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
+)
+
+// BusinessDateHandler shows and moves the property's business date.
+type BusinessDateHandler struct {
+	svc *propertyapp.BusinessDateService
+}
+
+func NewBusinessDateHandler(svc *propertyapp.BusinessDateService) *BusinessDateHandler {
+	return &BusinessDateHandler{svc: svc}
+}
+
+type businessDateDTO struct {
+	BusinessDate string `json:"businessDate"`
+	CalendarDate string `json:"calendarDate"`
+	Now          string `json:"now"`
+	Timezone     string `json:"timezone"`
+	TestMode     bool   `json:"testMode"`
+}
+
+type setClockDTO struct {
+	Now          string `json:"now"`
+	BusinessDate string `json:"businessDate"`
+}
+
+func (h *BusinessDateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasSuffix(strings.Trim(r.URL.Path, "/"), "/advance") {
+		h.handleAdvance(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.writeStatus(w)
+	case http.MethodPut:
+		h.handleSet(w, r)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *BusinessDateHandler) handleAdvance(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if _, err := h.svc.Advance(); err != nil {
+		status := http.StatusInternalServerError
+		if err == propertyapp.ErrBusinessDateAhead {
+			status = http.StatusConflict
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	h.writeStatus(w)
+}
+
+// handleSet pins the clock for acceptance tests; it is refused unless the
+// server runs in test mode.
+func (h *BusinessDateHandler) handleSet(w http.ResponseWriter, r *http.Request) {
+	var dto setClockDTO
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		http.Error(w, "invalid body", http.StatusBadRequest)
+		return
+	}
+
+	var now time.Time
+	if dto.Now != "" {
+		t, err := time.Parse(time.RFC3339, dto.Now)
+		if err != nil {
+			http.Error(w, "invalid now", http.StatusBadRequest)
+			return
+		}
+		now = t
+	}
+	var date propertydomain.Date
+	if dto.BusinessDate != "" {
+		d, err := propertydomain.ParseDate(dto.BusinessDate)
+		if err != nil {
+			http.Error(w, "invalid businessDate", http.StatusBadRequest)
+			return
+		}
+		date = d
+	}
+
+	if err := h.svc.Set(now, date); err != nil {
+		status := http.StatusInternalServerError
+		if err == propertyapp.ErrTestModeDisabled {
+			status = http.StatusForbidden
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	h.writeStatus(w)
+}
+
+func (h *BusinessDateHandler) writeStatus(w http.ResponseWriter) {
+	status := h.svc.Status()
+	writeJSON(w, businessDateDTO{
+		BusinessDate: status.BusinessDate.String(),
+		CalendarDate: status.CalendarDate.String(),
+		Now:          status.Now.Format(time.RFC3339),
+		Timezone:     status.Timezone,
+		TestMode:     status.TestMode,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, payload any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(payload)
+}
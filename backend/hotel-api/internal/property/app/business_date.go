@@ -0,0 +1,121 @@
+package app
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
+)
+
+var (
+	ErrBusinessDateAhead = errors.New("business date cannot run more than a day ahead of the calendar date")
+	ErrTestModeDisabled  = errors.New("the clock can only be set in test mode")
+)
+
+// BusinessDateService is the property's clock. The business date starts at
+// the calendar date the service is created on and is advanced explicitly,
+// normally by the night audit. In test mode the clock can also be moved to
+// any instant and the business date set freely, so acceptance tests run
+// against a known day.
+type BusinessDateService struct {
+	mu       sync.RWMutex
+	loc      *time.Location
+	testMode bool
+	wallFn   func() time.Time
+	// fixed pins the clock in test mode; it stays at the instant it was set
+	// to until it is set again.
+	fixed time.Time
+	date  propertydomain.Date
+}
+
+func NewBusinessDateService(loc *time.Location, testMode bool) *BusinessDateService {
+	s := &BusinessDateService{
+		loc:      loc,
+		testMode: testMode,
+		wallFn:   time.Now,
+	}
+	s.date = propertydomain.DateIn(s.wallFn(), loc)
+	return s
+}
+
+// BusinessDateStatus is the clock as shown to admins.
+type BusinessDateStatus struct {
+	BusinessDate propertydomain.Date
+	CalendarDate propertydomain.Date
+	Now          time.Time
+	Timezone     string
+	TestMode     bool
+}
+
+func (s *BusinessDateService) Now() time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.now()
+}
+
+func (s *BusinessDateService) now() time.Time {
+	if !s.fixed.IsZero() {
+		return s.fixed.In(s.loc)
+	}
+	return s.wallFn().In(s.loc)
+}
+
+func (s *BusinessDateService) BusinessDate() propertydomain.Date {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.date
+}
+
+func (s *BusinessDateService) Location() *time.Location {
+	return s.loc
+}
+
+func (s *BusinessDateService) Status() BusinessDateStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	now := s.now()
+	return BusinessDateStatus{
+		BusinessDate: s.date,
+		CalendarDate: propertydomain.DateIn(now, s.loc),
+		Now:          now,
+		Timezone:     s.loc.String(),
+		TestMode:     s.testMode,
+	}
+}
+
+// Advance closes the current business date and moves to the next one. Outside
+// test mode the business date may trail the calendar, as it does between
+// midnight and the night audit, or lead it by a day once the audit ran
+// before midnight, but no further.
+func (s *BusinessDateService) Advance() (propertydomain.Date, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	next := s.date.AddDays(1)
+	if !s.testMode && next.After(propertydomain.DateIn(s.now(), s.loc).AddDays(1)) {
+		return s.date, ErrBusinessDateAhead
+	}
+	s.date = next
+	return s.date, nil
+}
+
+// Set pins the clock at now and the business date to date in test mode. A
+// zero now leaves the clock alone; a zero date follows the new clock's
+// calendar date.
+func (s *BusinessDateService) Set(now time.Time, date propertydomain.Date) error {
+	if !s.testMode {
+		return ErrTestModeDisabled
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !now.IsZero() {
+		s.fixed = now
+	}
+	if date.IsZero() {
+		date = propertydomain.DateIn(s.now(), s.loc)
+	}
+	s.date = date
+	return nil
+}
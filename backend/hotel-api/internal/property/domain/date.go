@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Date is a calendar date with no time of day or zone. Stay dates are civil
+// dates: a night of 14 March is the same night wherever the server runs.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+const dateLayout = "2006-01-02"
+
+// DateOf returns t's calendar date in UTC.
+func DateOf(t time.Time) Date {
+	return DateIn(t, time.UTC)
+}
+
+// DateIn returns the calendar date at instant t as seen in loc.
+func DateIn(t time.Time, loc *time.Location) Date {
+	y, m, d := t.In(loc).Date()
+	return Date{Year: y, Month: m, Day: d}
+}
+
+// ParseDate parses a YYYY-MM-DD date.
+func ParseDate(value string) (Date, error) {
+	t, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return Date{}, fmt.Errorf("invalid date %q", value)
+	}
+	return DateOf(t), nil
+}
+
+func (d Date) String() string {
+	return d.Time().Format(dateLayout)
+}
+
+// Time returns midnight UTC on d.
+func (d Date) Time() time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, time.UTC)
+}
+
+// At returns the instant on d when a wall clock in loc shows the given time
+// of day, given as an offset from midnight. The offset is wall-clock time,
+// so 15h is 15:00 even on days a DST change makes 23 or 25 hours long.
+func (d Date) At(offset time.Duration, loc *time.Location) time.Time {
+	h := int(offset / time.Hour)
+	m := int(offset % time.Hour / time.Minute)
+	s := int(offset % time.Minute / time.Second)
+	return time.Date(d.Year, d.Month, d.Day, h, m, s, 0, loc)
+}
+
+func (d Date) AddDays(n int) Date {
+	return DateOf(d.Time().AddDate(0, 0, n))
+}
+
+// DaysUntil counts calendar days from d to other; negative if other is
+// earlier.
+func (d Date) DaysUntil(other Date) int {
+	return int(other.Time().Sub(d.Time()).Hours() / 24)
+}
+
+func (d Date) Before(other Date) bool {
+	return d.Time().Before(other.Time())
+}
+
+func (d Date) After(other Date) bool {
+	return d.Time().After(other.Time())
+}
+
+// Compare returns -1 if d is before other, +1 if after and 0 if equal.
+func (d Date) Compare(other Date) int {
+	return d.Time().Compare(other.Time())
+}
+
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
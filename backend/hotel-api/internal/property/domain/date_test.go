@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("load %s: %v", name, err)
+	}
+	return loc
+}
+
+func TestDateInAcrossDSTChanges(t *testing.T) {
+	newYork := mustLoad(t, "America/New_York")
+	berlin := mustLoad(t, "Europe/Berlin")
+
+	tests := []struct {
+		name string
+		at   string
+		loc  *time.Location
+		want Date
+	}{
+		{"spring forward, last minute of the day before", "2025-03-09T04:59:00Z", newYork, Date{2025, time.March, 8}},
+		{"spring forward, just before the change", "2025-03-09T06:59:59Z", newYork, Date{2025, time.March, 9}},
+		{"spring forward, just after the change", "2025-03-09T07:00:00Z", newYork, Date{2025, time.March, 9}},
+		{"spring forward, last minute of the short day", "2025-03-10T03:59:00Z", newYork, Date{2025, time.March, 9}},
+		{"spring forward, next midnight", "2025-03-10T04:00:00Z", newYork, Date{2025, time.March, 10}},
+		{"fall back, last minute of the day before", "2025-11-02T03:59:00Z", newYork, Date{2025, time.November, 1}},
+		{"fall back, first 01:30", "2025-11-02T05:30:00Z", newYork, Date{2025, time.November, 2}},
+		{"fall back, repeated 01:30", "2025-11-02T06:30:00Z", newYork, Date{2025, time.November, 2}},
+		{"fall back, last minute of the long day", "2025-11-03T04:59:00Z", newYork, Date{2025, time.November, 2}},
+		{"fall back, next midnight", "2025-11-03T05:00:00Z", newYork, Date{2025, time.November, 3}},
+		{"east of UTC, spring forward evening", "2025-03-30T22:30:00Z", berlin, Date{2025, time.March, 31}},
+		{"east of UTC, fall back evening", "2025-10-26T22:30:00Z", berlin, Date{2025, time.October, 26}},
+		{"UTC", "2025-03-09T23:59:00Z", time.UTC, Date{2025, time.March, 9}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			at, err := time.Parse(time.RFC3339, tt.at)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := DateIn(at, tt.loc); got != tt.want {
+				t.Errorf("DateIn(%s, %s) = %s, want %s", tt.at, tt.loc, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDateAtAcrossDSTChanges(t *testing.T) {
+	newYork := mustLoad(t, "America/New_York")
+	berlin := mustLoad(t, "Europe/Berlin")
+
+	tests := []struct {
+		name   string
+		date   Date
+		offset time.Duration
+		loc    *time.Location
+		want   string
+	}{
+		{"spring forward, before the change", Date{2025, time.March, 9}, time.Hour, newYork, "2025-03-09T06:00:00Z"},
+		{"spring forward, check-out", Date{2025, time.March, 9}, 11 * time.Hour, newYork, "2025-03-09T15:00:00Z"},
+		{"spring forward, check-in", Date{2025, time.March, 9}, 15 * time.Hour, newYork, "2025-03-09T19:00:00Z"},
+		{"fall back, midnight", Date{2025, time.November, 2}, 0, newYork, "2025-11-02T04:00:00Z"},
+		{"fall back, check-out", Date{2025, time.November, 2}, 11 * time.Hour, newYork, "2025-11-02T16:00:00Z"},
+		{"fall back, check-in with minutes", Date{2025, time.November, 2}, 15*time.Hour + 30*time.Minute, newYork, "2025-11-02T20:30:00Z"},
+		{"east of UTC, spring forward", Date{2025, time.March, 30}, 15 * time.Hour, berlin, "2025-03-30T13:00:00Z"},
+		{"east of UTC, fall back", Date{2025, time.October, 26}, 11 * time.Hour, berlin, "2025-10-26T10:00:00Z"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.date.At(tt.offset, tt.loc)
+			if s := got.UTC().Format(time.RFC3339); s != tt.want {
+				t.Errorf("%s.At(%s, %s) = %s, want %s", tt.date, tt.offset, tt.loc, s, tt.want)
+			}
+			if DateIn(got, tt.loc) != tt.date {
+				t.Errorf("%s.At(%s, %s) is on %s locally", tt.date, tt.offset, tt.loc, DateIn(got, tt.loc))
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+// Property is the hotel the bookings belong to, as shown to guests and on
+// invoices.
+type Property struct {
+	ID      string
+	Name    string
+	Address string
+	Phone   string
+	Email   string
+	// TaxID is the property's tax registration number printed on invoices.
+	TaxID string
+	// InvoicePrefix starts every invoice and credit note number.
+	InvoicePrefix string
+	// Timezone is the hotel's local time, in which stay dates, check-in and
+	// check-out times and "today" are reckoned. Nil means UTC.
+	Timezone *time.Location
+}
+
+// Location returns the property's timezone, defaulting to UTC.
+func (p Property) Location() *time.Location {
+	if p.Timezone == nil {
+		return time.UTC
+	}
+	return p.Timezone
+}
@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"time"
+
+	"github.com/yourorg/hotel-api/internal/property/domain"
+)
+
+// Clock is the single source of time for the property. Now is the current
+// instant; BusinessDate is the hotel day front-desk operations belong to,
+// which only moves forward when the night audit closes the day.
+type Clock interface {
+	Now() time.Time
+	BusinessDate() domain.Date
+	Location() *time.Location
+}
+
+// DayCloser is a Clock whose business date the night audit rolls forward.
+type DayCloser interface {
+	Clock
+	Advance() (domain.Date, error)
+}
@@ -0,0 +1,100 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	roomapp "github.com/yourorg/hotel-api/internal/room/app"
+	roomdomain "github.com/yourorg/hotel-api/internal/room/domain"
+)
+
+// OccupancyHandler serves occupancy pricing under /api/admin/occupancy:
+//
+//	GET /api/admin/occupancy  age bands and extra-guest charges per room type
+//	PUT /api/admin/occupancy  replace them
+type OccupancyHandler struct {
+	svc *roomapp.OccupancyService
+}
+
+func NewOccupancyHandler(svc *roomapp.OccupancyService) *OccupancyHandler {
+	return &OccupancyHandler{svc: svc}
+}
+
+type occupancyDTO struct {
+	AgeBands []ageBandDTO       `json:"ageBands"`
+	Rules    []occupancyRuleDTO `json:"rules"`
+}
+
+type ageBandDTO struct {
+	Name   string `json:"name"`
+	MaxAge int    `json:"maxAge"`
+}
+
+type occupancyRuleDTO struct {
+	RoomType      string             `json:"roomType"`
+	BaseOccupancy int                `json:"baseOccupancy"`
+	ExtraAdult    float64            `json:"extraAdult"`
+	ExtraChild    map[string]float64 `json:"extraChild"`
+}
+
+func (h *OccupancyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		pricing, err := h.svc.Get(r.Context())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, toOccupancyDTO(pricing))
+	case http.MethodPut:
+		var dto occupancyDTO
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			http.Error(w, "invalid body", http.StatusBadRequest)
+			return
+		}
+		var pricing roomdomain.OccupancyPricing
+		for _, b := range dto.AgeBands {
+			pricing.Bands = append(pricing.Bands, roomdomain.AgeBand{Name: b.Name, MaxAge: b.MaxAge})
+		}
+		for _, rule := range dto.Rules {
+			pricing.Rules = append(pricing.Rules, roomdomain.OccupancyRule{
+				RoomType:      rule.RoomType,
+				BaseOccupancy: rule.BaseOccupancy,
+				ExtraAdult:    rule.ExtraAdult,
+				ExtraChild:    rule.ExtraChild,
+			})
+		}
+		saved, err := h.svc.Save(r.Context(), pricing)
+		if err != nil {
+			status := http.StatusInternalServerError
+			if err == roomapp.ErrInvalidOccupancy {
+				status = http.StatusBadRequest
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+		writeJSON(w, toOccupancyDTO(saved))
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func toOccupancyDTO(p roomdomain.OccupancyPricing) occupancyDTO {
+	dto := occupancyDTO{AgeBands: []ageBandDTO{}, Rules: []occupancyRuleDTO{}}
+	for _, b := range p.Bands {
+		dto.AgeBands = append(dto.AgeBands, ageBandDTO{Name: b.Name, MaxAge: b.MaxAge})
+	}
+	for _, r := range p.Rules {
+		extra := map[string]float64{}
+		for band, charge := range r.ExtraChild {
+			extra[band] = charge
+		}
+		dto.Rules = append(dto.Rules, occupancyRuleDTO{
+			RoomType:      r.RoomType,
+			BaseOccupancy: r.BaseOccupancy,
+			ExtraAdult:    r.ExtraAdult,
+			ExtraChild:    extra,
+		})
+	}
+	return dto
+}
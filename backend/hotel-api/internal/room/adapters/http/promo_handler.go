@@ -0,0 +1,169 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
+	roomapp "github.com/yourorg/hotel-api/internal/room/app"
+	roomdomain "github.com/yourorg/hotel-api/internal/room/domain"
+)
+
+// PromoHandler serves promo codes under /api/admin/promos:
+//
+//	GET    /api/admin/promos         list codes with their redemption counts
+//	POST   /api/admin/promos         create a code
+//	PUT    /api/admin/promos/{code}  replace a code's terms
+//	DELETE /api/admin/promos/{code}  delete a code
+type PromoHandler struct {
+	svc *roomapp.PromoService
+}
+
+func NewPromoHandler(svc *roomapp.PromoService) *PromoHandler {
+	return &PromoHandler{svc: svc}
+}
+
+type promoDTO struct {
+	Code             string   `json:"code"`
+	Description      string   `json:"description"`
+	Percent          float64  `json:"percent,omitempty"`
+	Amount           float64  `json:"amount,omitempty"`
+	ValidFrom        string   `json:"validFrom,omitempty"`
+	ValidTo          string   `json:"validTo,omitempty"`
+	StayFrom         string   `json:"stayFrom,omitempty"`
+	StayTo           string   `json:"stayTo,omitempty"`
+	MinNights        int      `json:"minNights"`
+	RoomTypes        []string `json:"roomTypes"`
+	MaxUses          int      `json:"maxUses"`
+	MaxUsesPerGuest  int      `json:"maxUsesPerGuest"`
+	FirstBookingOnly bool     `json:"firstBookingOnly"`
+	Stackable        bool     `json:"stackable"`
+	Active           bool     `json:"active"`
+	Uses             int      `json:"uses"`
+}
+
+func (h *PromoHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case len(parts) == 3 && r.Method == http.MethodGet:
+		list, err := h.svc.List(r.Context())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		dtos := make([]promoDTO, 0, len(list))
+		for _, u := range list {
+			dto := toPromoDTO(u.Promo)
+			dto.Uses = u.Uses
+			dtos = append(dtos, dto)
+		}
+		writeJSON(w, dtos)
+	case len(parts) == 3 && r.Method == http.MethodPost:
+		h.handleSave(w, r, "")
+	case len(parts) == 4 && r.Method == http.MethodPut:
+		h.handleSave(w, r, parts[3])
+	case len(parts) == 4 && r.Method == http.MethodDelete:
+		if err := h.svc.Delete(r.Context(), parts[3]); err != nil {
+			writePromoError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *PromoHandler) handleSave(w http.ResponseWriter, r *http.Request, code string) {
+	var dto promoDTO
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		http.Error(w, "invalid body", http.StatusBadRequest)
+		return
+	}
+	var dates [4]propertydomain.Date
+	for i, value := range []string{dto.ValidFrom, dto.ValidTo, dto.StayFrom, dto.StayTo} {
+		d, err := parseOptionalDate(value)
+		if err != nil {
+			http.Error(w, "invalid date", http.StatusBadRequest)
+			return
+		}
+		dates[i] = d
+	}
+
+	promo := roomdomain.Promo{
+		Code:             dto.Code,
+		Description:      strings.TrimSpace(dto.Description),
+		Percent:          dto.Percent,
+		Amount:           dto.Amount,
+		ValidFrom:        dates[0],
+		ValidTo:          dates[1],
+		StayFrom:         dates[2],
+		StayTo:           dates[3],
+		MinNights:        dto.MinNights,
+		RoomTypes:        dto.RoomTypes,
+		MaxUses:          dto.MaxUses,
+		MaxUsesPerGuest:  dto.MaxUsesPerGuest,
+		FirstBookingOnly: dto.FirstBookingOnly,
+		Stackable:        dto.Stackable,
+		Active:           dto.Active,
+	}
+	if code == "" {
+		saved, err := h.svc.Create(r.Context(), promo)
+		if err != nil {
+			writePromoError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(toPromoDTO(*saved))
+		return
+	}
+
+	promo.Code = code
+	saved, err := h.svc.Update(r.Context(), promo)
+	if err != nil {
+		writePromoError(w, err)
+		return
+	}
+	writeJSON(w, toPromoDTO(*saved))
+}
+
+func writePromoError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch err {
+	case roomapp.ErrInvalidPromo:
+		status = http.StatusBadRequest
+	case roomapp.ErrPromoNotFound:
+		status = http.StatusNotFound
+	case roomapp.ErrPromoExists:
+		status = http.StatusConflict
+	}
+	http.Error(w, err.Error(), status)
+}
+
+func toPromoDTO(p roomdomain.Promo) promoDTO {
+	return promoDTO{
+		Code:             p.Code,
+		Description:      p.Description,
+		Percent:          p.Percent,
+		Amount:           p.Amount,
+		ValidFrom:        formatOptionalDate(p.ValidFrom),
+		ValidTo:          formatOptionalDate(p.ValidTo),
+		StayFrom:         formatOptionalDate(p.StayFrom),
+		StayTo:           formatOptionalDate(p.StayTo),
+		MinNights:        p.MinNights,
+		RoomTypes:        append([]string{}, p.RoomTypes...),
+		MaxUses:          p.MaxUses,
+		MaxUsesPerGuest:  p.MaxUsesPerGuest,
+		FirstBookingOnly: p.FirstBookingOnly,
+		Stackable:        p.Stackable,
+		Active:           p.Active,
+	}
+}
+
+func formatOptionalDate(d propertydomain.Date) string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.String()
+}
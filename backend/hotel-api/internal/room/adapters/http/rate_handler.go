@@ -0,0 +1,258 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
+	roomapp "github.com/yourorg/hotel-api/internal/room/app"
+	roomdomain "github.com/yourorg/hotel-api/internal/room/domain"
+)
+
+// RateHandler serves the rate calendar under /api/admin/rates:
+//
+//	GET    /api/admin/rates?roomType&from&to   nightly prices per room type
+//	GET    /api/admin/rates/seasons            list seasons
+//	POST   /api/admin/rates/seasons            create a season
+//	PUT    /api/admin/rates/seasons/{id}       replace a season
+//	DELETE /api/admin/rates/seasons/{id}       delete a season
+//	GET    /api/admin/rates/overrides?from&to  list per-date overrides
+//	PUT    /api/admin/rates/overrides          set an override, optionally through a date
+//	DELETE /api/admin/rates/overrides?roomType&date
+type RateHandler struct {
+	svc *roomapp.RateService
+}
+
+func NewRateHandler(svc *roomapp.RateService) *RateHandler {
+	return &RateHandler{svc: svc}
+}
+
+type typeRateDTO struct {
+	RoomType string  `json:"roomType"`
+	Date     string  `json:"date"`
+	Price    float64 `json:"price"`
+	Source   string  `json:"source"`
+}
+
+type seasonDTO struct {
+	ID           string  `json:"id"`
+	RoomType     string  `json:"roomType"`
+	Name         string  `json:"name"`
+	From         string  `json:"from"`
+	To           string  `json:"to"`
+	Price        float64 `json:"price"`
+	WeekendPrice float64 `json:"weekendPrice,omitempty"`
+}
+
+type rateOverrideDTO struct {
+	RoomType string  `json:"roomType"`
+	Date     string  `json:"date"`
+	Through  string  `json:"through,omitempty"`
+	Price    float64 `json:"price"`
+}
+
+func (h *RateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case len(parts) == 3:
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		h.handleCalendar(w, r)
+	case len(parts) >= 4 && parts[3] == "seasons":
+		h.serveSeasons(w, r, parts[4:])
+	case len(parts) == 4 && parts[3] == "overrides":
+		h.serveOverrides(w, r)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (h *RateHandler) handleCalendar(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	from, err1 := propertydomain.ParseDate(q.Get("from"))
+	to, err2 := propertydomain.ParseDate(q.Get("to"))
+	if err1 != nil || err2 != nil {
+		http.Error(w, "from and to dates required", http.StatusBadRequest)
+		return
+	}
+
+	rates, err := h.svc.Calendar(r.Context(), q.Get("roomType"), from, to)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err == roomapp.ErrInvalidDateRange {
+			status = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	dtos := make([]typeRateDTO, 0, len(rates))
+	for _, rate := range rates {
+		dtos = append(dtos, typeRateDTO{
+			RoomType: rate.RoomType,
+			Date:     rate.Date.String(),
+			Price:    rate.Price,
+			Source:   rate.Source,
+		})
+	}
+	writeJSON(w, dtos)
+}
+
+func (h *RateHandler) serveSeasons(w http.ResponseWriter, r *http.Request, rest []string) {
+	switch {
+	case len(rest) == 0 && r.Method == http.MethodGet:
+		seasons, err := h.svc.Seasons(r.Context())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		dtos := make([]seasonDTO, 0, len(seasons))
+		for _, season := range seasons {
+			dtos = append(dtos, toSeasonDTO(season))
+		}
+		writeJSON(w, dtos)
+	case len(rest) == 0 && r.Method == http.MethodPost:
+		h.handleSaveSeason(w, r, "")
+	case len(rest) == 1 && r.Method == http.MethodPut:
+		h.handleSaveSeason(w, r, rest[0])
+	case len(rest) == 1 && r.Method == http.MethodDelete:
+		if err := h.svc.DeleteSeason(r.Context(), rest[0]); err != nil {
+			writeSeasonError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *RateHandler) handleSaveSeason(w http.ResponseWriter, r *http.Request, id string) {
+	var dto seasonDTO
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		http.Error(w, "invalid body", http.StatusBadRequest)
+		return
+	}
+	from, err1 := propertydomain.ParseDate(dto.From)
+	to, err2 := propertydomain.ParseDate(dto.To)
+	if err1 != nil || err2 != nil {
+		http.Error(w, "invalid from or to", http.StatusBadRequest)
+		return
+	}
+
+	season, err := h.svc.SaveSeason(r.Context(), roomdomain.Season{
+		ID:           id,
+		RoomType:     dto.RoomType,
+		Name:         dto.Name,
+		From:         from,
+		To:           to,
+		Price:        dto.Price,
+		WeekendPrice: dto.WeekendPrice,
+	})
+	if err != nil {
+		writeSeasonError(w, err)
+		return
+	}
+	if id == "" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(toSeasonDTO(*season))
+		return
+	}
+	writeJSON(w, toSeasonDTO(*season))
+}
+
+func writeSeasonError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch err {
+	case roomapp.ErrInvalidSeason:
+		status = http.StatusBadRequest
+	case roomapp.ErrSeasonNotFound:
+		status = http.StatusNotFound
+	}
+	http.Error(w, err.Error(), status)
+}
+
+func toSeasonDTO(s roomdomain.Season) seasonDTO {
+	return seasonDTO{
+		ID:           s.ID,
+		RoomType:     s.RoomType,
+		Name:         s.Name,
+		From:         s.From.String(),
+		To:           s.To.String(),
+		Price:        s.Price,
+		WeekendPrice: s.WeekendPrice,
+	}
+}
+
+func (h *RateHandler) serveOverrides(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		from, err1 := parseOptionalDate(r.URL.Query().Get("from"))
+		to, err2 := parseOptionalDate(r.URL.Query().Get("to"))
+		if err1 != nil || err2 != nil {
+			http.Error(w, "invalid date", http.StatusBadRequest)
+			return
+		}
+		overrides, err := h.svc.Overrides(r.Context(), from, to)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		dtos := make([]rateOverrideDTO, 0, len(overrides))
+		for _, o := range overrides {
+			dtos = append(dtos, rateOverrideDTO{RoomType: o.RoomType, Date: o.Date.String(), Price: o.Price})
+		}
+		writeJSON(w, dtos)
+	case http.MethodPut:
+		h.handleSetOverride(w, r)
+	case http.MethodDelete:
+		date, err := propertydomain.ParseDate(r.URL.Query().Get("date"))
+		if err != nil {
+			http.Error(w, "invalid date", http.StatusBadRequest)
+			return
+		}
+		if err := h.svc.DeleteOverride(r.Context(), r.URL.Query().Get("roomType"), date); err != nil {
+			status := http.StatusInternalServerError
+			if err == roomapp.ErrRateOverrideMissing {
+				status = http.StatusNotFound
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *RateHandler) handleSetOverride(w http.ResponseWriter, r *http.Request) {
+	var dto rateOverrideDTO
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		http.Error(w, "invalid body", http.StatusBadRequest)
+		return
+	}
+	date, err := propertydomain.ParseDate(dto.Date)
+	if err != nil {
+		http.Error(w, "invalid date", http.StatusBadRequest)
+		return
+	}
+	through, err := parseOptionalDate(dto.Through)
+	if err != nil {
+		http.Error(w, "invalid through", http.StatusBadRequest)
+		return
+	}
+
+	err = h.svc.SetOverride(r.Context(), roomdomain.RateOverride{RoomType: dto.RoomType, Date: date, Price: dto.Price}, through)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err == roomapp.ErrInvalidRateOverride {
+			status = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,149 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	roomapp "github.com/yourorg/hotel-api/internal/room/app"
+	roomdomain "github.com/yourorg/hotel-api/internal/room/domain"
+)
+
+// RatePlanHandler serves the rate plans under /api/admin/rate-plans:
+//
+//	GET    /api/admin/rate-plans       list plans
+//	POST   /api/admin/rate-plans       create a plan
+//	PUT    /api/admin/rate-plans/{id}  replace a plan
+//	DELETE /api/admin/rate-plans/{id}  delete a plan
+type RatePlanHandler struct {
+	svc *roomapp.RatePlanService
+}
+
+func NewRatePlanHandler(svc *roomapp.RatePlanService) *RatePlanHandler {
+	return &RatePlanHandler{svc: svc}
+}
+
+type ratePlanDTO struct {
+	ID           string                `json:"id"`
+	Name         string                `json:"name"`
+	RoomTypes    []string              `json:"roomTypes"`
+	Channels     []string              `json:"channels"`
+	Price        priceRuleDTO          `json:"price"`
+	Cancellation cancellationPolicyDTO `json:"cancellation"`
+	Inclusions   []string              `json:"inclusions"`
+	Active       bool                  `json:"active"`
+}
+
+type priceRuleDTO struct {
+	Percent  float64 `json:"percent"`
+	PerNight float64 `json:"perNight"`
+	PerGuest float64 `json:"perGuest"`
+}
+
+type cancellationPolicyDTO struct {
+	NonRefundable         bool    `json:"nonRefundable"`
+	FreeCancellationHours float64 `json:"freeCancellationHours"`
+	FeeNights             float64 `json:"feeNights"`
+}
+
+func (h *RatePlanHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case len(parts) == 3 && r.Method == http.MethodGet:
+		plans, err := h.svc.List(r.Context())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		dtos := make([]ratePlanDTO, 0, len(plans))
+		for _, plan := range plans {
+			dtos = append(dtos, toRatePlanDTO(plan))
+		}
+		writeJSON(w, dtos)
+	case len(parts) == 3 && r.Method == http.MethodPost:
+		h.handleSave(w, r, "")
+	case len(parts) == 4 && r.Method == http.MethodPut:
+		h.handleSave(w, r, parts[3])
+	case len(parts) == 4 && r.Method == http.MethodDelete:
+		if err := h.svc.Delete(r.Context(), parts[3]); err != nil {
+			writeRatePlanError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// handleSave creates or replaces a plan. Plans are sold unless the body
+// sets active to false.
+func (h *RatePlanHandler) handleSave(w http.ResponseWriter, r *http.Request, id string) {
+	dto := ratePlanDTO{Active: true}
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		http.Error(w, "invalid body", http.StatusBadRequest)
+		return
+	}
+
+	plan, err := h.svc.Save(r.Context(), roomdomain.RatePlan{
+		ID:        id,
+		Name:      dto.Name,
+		RoomTypes: dto.RoomTypes,
+		Channels:  dto.Channels,
+		Price: roomdomain.PriceRule{
+			Percent:  dto.Price.Percent,
+			PerNight: dto.Price.PerNight,
+			PerGuest: dto.Price.PerGuest,
+		},
+		Cancellation: roomdomain.CancellationPolicy{
+			NonRefundable:    dto.Cancellation.NonRefundable,
+			FreeCancellation: time.Duration(dto.Cancellation.FreeCancellationHours * float64(time.Hour)),
+			FeeNights:        dto.Cancellation.FeeNights,
+		},
+		Inclusions: dto.Inclusions,
+		Active:     dto.Active,
+	})
+	if err != nil {
+		writeRatePlanError(w, err)
+		return
+	}
+	if id == "" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(toRatePlanDTO(*plan))
+		return
+	}
+	writeJSON(w, toRatePlanDTO(*plan))
+}
+
+func writeRatePlanError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch err {
+	case roomapp.ErrInvalidRatePlan:
+		status = http.StatusBadRequest
+	case roomapp.ErrRatePlanNotFound:
+		status = http.StatusNotFound
+	}
+	http.Error(w, err.Error(), status)
+}
+
+func toRatePlanDTO(p roomdomain.RatePlan) ratePlanDTO {
+	return ratePlanDTO{
+		ID:        p.ID,
+		Name:      p.Name,
+		RoomTypes: append([]string{}, p.RoomTypes...),
+		Channels:  append([]string{}, p.Channels...),
+		Price: priceRuleDTO{
+			Percent:  p.Price.Percent,
+			PerNight: p.Price.PerNight,
+			PerGuest: p.Price.PerGuest,
+		},
+		Cancellation: cancellationPolicyDTO{
+			NonRefundable:         p.Cancellation.NonRefundable,
+			FreeCancellationHours: p.Cancellation.FreeCancellation.Hours(),
+			FeeNights:             p.Cancellation.FeeNights,
+		},
+		Inclusions: append([]string{}, p.Inclusions...),
+		Active:     p.Active,
+	}
+}
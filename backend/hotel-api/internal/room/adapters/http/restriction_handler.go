@@ -0,0 +1,132 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
+	roomapp "github.com/yourorg/hotel-api/internal/room/app"
+	roomdomain "github.com/yourorg/hotel-api/internal/room/domain"
+)
+
+// RestrictionHandler lets revenue staff manage stay restrictions per room
+// type and date.
+type RestrictionHandler struct {
+	svc *roomapp.RestrictionService
+}
+
+func NewRestrictionHandler(svc *roomapp.RestrictionService) *RestrictionHandler {
+	return &RestrictionHandler{svc: svc}
+}
+
+type restrictionDTO struct {
+	RoomType          string `json:"roomType,omitempty"`
+	Date              string `json:"date"`
+	Through           string `json:"through,omitempty"`
+	MinStay           int    `json:"minStay,omitempty"`
+	MaxStay           int    `json:"maxStay,omitempty"`
+	ClosedToArrival   bool   `json:"closedToArrival"`
+	ClosedToDeparture bool   `json:"closedToDeparture"`
+}
+
+func (h *RestrictionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.handleList(w, r)
+	case http.MethodPut:
+		h.handleSet(w, r)
+	case http.MethodDelete:
+		h.handleDelete(w, r)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *RestrictionHandler) handleList(w http.ResponseWriter, r *http.Request) {
+	from, err1 := parseOptionalDate(r.URL.Query().Get("from"))
+	to, err2 := parseOptionalDate(r.URL.Query().Get("to"))
+	if err1 != nil || err2 != nil {
+		http.Error(w, "invalid date", http.StatusBadRequest)
+		return
+	}
+
+	restrictions, err := h.svc.List(r.Context(), from, to)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	dtos := make([]restrictionDTO, 0, len(restrictions))
+	for _, sr := range restrictions {
+		dtos = append(dtos, restrictionDTO{
+			RoomType:          sr.RoomType,
+			Date:              sr.Date.String(),
+			MinStay:           sr.MinStay,
+			MaxStay:           sr.MaxStay,
+			ClosedToArrival:   sr.ClosedToArrival,
+			ClosedToDeparture: sr.ClosedToDeparture,
+		})
+	}
+	writeJSON(w, dtos)
+}
+
+func (h *RestrictionHandler) handleSet(w http.ResponseWriter, r *http.Request) {
+	var dto restrictionDTO
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		http.Error(w, "invalid body", http.StatusBadRequest)
+		return
+	}
+	date, err := propertydomain.ParseDate(dto.Date)
+	if err != nil {
+		http.Error(w, "invalid date", http.StatusBadRequest)
+		return
+	}
+	through, err := parseOptionalDate(dto.Through)
+	if err != nil {
+		http.Error(w, "invalid through", http.StatusBadRequest)
+		return
+	}
+
+	err = h.svc.Set(r.Context(), roomdomain.StayRestriction{
+		RoomType:          dto.RoomType,
+		Date:              date,
+		MinStay:           dto.MinStay,
+		MaxStay:           dto.MaxStay,
+		ClosedToArrival:   dto.ClosedToArrival,
+		ClosedToDeparture: dto.ClosedToDeparture,
+	}, through)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err == roomapp.ErrInvalidRestriction {
+			status = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *RestrictionHandler) handleDelete(w http.ResponseWriter, r *http.Request) {
+	date, err := propertydomain.ParseDate(r.URL.Query().Get("date"))
+	if err != nil {
+		http.Error(w, "invalid date", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.svc.Delete(r.Context(), r.URL.Query().Get("roomType"), date); err != nil {
+		status := http.StatusInternalServerError
+		if err == roomapp.ErrRestrictionNotFound {
+			status = http.StatusNotFound
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func parseOptionalDate(value string) (propertydomain.Date, error) {
+	if value == "" {
+		return propertydomain.Date{}, nil
+	}
+	return propertydomain.ParseDate(value)
+}
@@ -2,11 +2,14 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
-	"time"
+	"strings"
 
+	propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
 	roomapp "github.com/yourorg/hotel-api/internal/room/app"
+	roomdomain "github.com/yourorg/hotel-api/internal/room/domain"
 )
 
 type searchHandler struct {
@@ -18,16 +21,77 @@ func NewSearchHandler(svc *roomapp.SearchService) http.Handler {
 }
 
 type searchResponseDTO struct {
-	Rooms []roomDTO `json:"rooms"`
+	Rooms      []roomDTO              `json:"rooms"`
+	RoomTypes  []roomTypeAvailableDTO `json:"roomTypes"`
+	Restricted []restrictedTypeDTO    `json:"restricted"`
+}
+
+type restrictedTypeDTO struct {
+	Type    string `json:"type"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type roomTypeAvailableDTO struct {
+	Type      string            `json:"type"`
+	Available int               `json:"available"`
+	Capacity  int               `json:"capacity"`
+	FromPrice float64           `json:"fromPrice"`
+	Nightly   []nightlyPriceDTO `json:"nightly"`
+	Total     float64           `json:"total"`
+	Plans     []planPriceDTO    `json:"plans"`
 }
 
 type roomDTO struct {
-	ID        string  `json:"id"`
-	Name      string  `json:"name"`
-	Type      string  `json:"type"`
-	Capacity  int     `json:"capacity"`
-	BasePrice float64 `json:"basePrice"`
-	Status    string  `json:"status"`
+	ID        string            `json:"id"`
+	Name      string            `json:"name"`
+	Type      string            `json:"type"`
+	Capacity  int               `json:"capacity"`
+	BasePrice float64           `json:"basePrice"`
+	Status    string            `json:"status"`
+	Nightly   []nightlyPriceDTO `json:"nightly"`
+	Total     float64           `json:"total"`
+	Discount  float64           `json:"discount"`
+	Plans     []planPriceDTO    `json:"plans"`
+}
+
+type planPriceDTO struct {
+	ID            string            `json:"id"`
+	Name          string            `json:"name"`
+	NonRefundable bool              `json:"nonRefundable"`
+	Inclusions    []string          `json:"inclusions"`
+	Nightly       []nightlyPriceDTO `json:"nightly"`
+	Total         float64           `json:"total"`
+	Discount      float64           `json:"discount"`
+}
+
+type nightlyPriceDTO struct {
+	Date  string  `json:"date"`
+	Price float64 `json:"price"`
+}
+
+func toPlanPriceDTOs(prices []roomapp.PlanPrice) []planPriceDTO {
+	dtos := make([]planPriceDTO, 0, len(prices))
+	for _, p := range prices {
+		dtos = append(dtos, planPriceDTO{
+			ID:            p.Plan.ID,
+			Name:          p.Plan.Name,
+			NonRefundable: p.Plan.Cancellation.NonRefundable,
+			Inclusions:    append([]string{}, p.Plan.Inclusions...),
+			Nightly:       toNightlyPriceDTOs(p.Nightly),
+			Total:         p.Total,
+			Discount:      p.Discount,
+		})
+	}
+	return dtos
+}
+
+func toNightlyPriceDTOs(rates []roomdomain.NightlyRate) []nightlyPriceDTO {
+	dtos := make([]nightlyPriceDTO, 0, len(rates))
+	for _, rate := range rates {
+		dtos = append(dtos, nightlyPriceDTO{Date: rate.Date.String(), Price: rate.Price})
+	}
+	return dtos
 }
 
 func (h *searchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -40,12 +104,12 @@ func (h *searchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	checkOutStr := r.URL.Query().Get("checkOut")
 	guestsStr := r.URL.Query().Get("guests")
 
-	checkIn, err := time.Parse("2006-01-02", checkInStr)
+	checkIn, err := propertydomain.ParseDate(checkInStr)
 	if err != nil {
 		http.Error(w, "invalid checkIn", http.StatusBadRequest)
 		return
 	}
-	checkOut, err := time.Parse("2006-01-02", checkOutStr)
+	checkOut, err := propertydomain.ParseDate(checkOutStr)
 	if err != nil {
 		http.Error(w, "invalid checkOut", http.StatusBadRequest)
 		return
@@ -59,14 +123,44 @@ func (h *searchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	rooms, err := h.svc.Search(r.Context(), roomapp.SearchInput{
-		CheckIn:  checkIn,
-		CheckOut: checkOut,
-		Guests:   guests,
+	var childAges []int
+	if raw := r.URL.Query().Get("childAges"); raw != "" {
+		for _, part := range strings.Split(raw, ",") {
+			age, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil {
+				http.Error(w, "invalid childAges", http.StatusBadRequest)
+				return
+			}
+			childAges = append(childAges, age)
+		}
+	}
+
+	result, err := h.svc.Search(r.Context(), roomapp.SearchInput{
+		CheckIn:   checkIn,
+		CheckOut:  checkOut,
+		Guests:    guests,
+		ChildAges: childAges,
+		Channel:   roomdomain.ChannelWeb,
+		PromoCode: r.URL.Query().Get("promo"),
 	})
+	var violation *roomdomain.RestrictionError
+	if errors.As(err, &violation) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_ = json.NewEncoder(w).Encode(map[string]string{"code": violation.Code, "message": violation.Error()})
+		return
+	}
+	var promoErr *roomdomain.PromoError
+	if errors.As(err, &promoErr) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_ = json.NewEncoder(w).Encode(map[string]string{"code": promoErr.Code, "message": promoErr.Error()})
+		return
+	}
 	if err != nil {
 		status := http.StatusInternalServerError
-		if err == roomapp.ErrInvalidDateRange {
+		switch err {
+		case roomapp.ErrInvalidDateRange, roomdomain.ErrInvalidParty, roomapp.ErrPromoNotFound:
 			status = http.StatusBadRequest
 		}
 		http.Error(w, err.Error(), status)
@@ -74,9 +168,30 @@ func (h *searchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	resp := searchResponseDTO{
-		Rooms: make([]roomDTO, 0, len(rooms)),
+		Rooms:      make([]roomDTO, 0, len(result.Rooms)),
+		RoomTypes:  make([]roomTypeAvailableDTO, 0, len(result.RoomTypes)),
+		Restricted: make([]restrictedTypeDTO, 0, len(result.Restricted)),
+	}
+	for _, t := range result.Restricted {
+		resp.Restricted = append(resp.Restricted, restrictedTypeDTO{
+			Type:    t.Type,
+			Code:    t.Violation.Code,
+			Message: t.Violation.Error(),
+		})
+	}
+	for _, t := range result.RoomTypes {
+		resp.RoomTypes = append(resp.RoomTypes, roomTypeAvailableDTO{
+			Type:      t.Type,
+			Available: t.Available,
+			Capacity:  t.Capacity,
+			FromPrice: t.FromPrice,
+			Nightly:   toNightlyPriceDTOs(t.Nightly),
+			Total:     t.Total,
+			Plans:     toPlanPriceDTOs(t.Plans),
+		})
 	}
-	for _, room := range rooms {
+	for _, available := range result.Rooms {
+		room := available.Room
 		resp.Rooms = append(resp.Rooms, roomDTO{
 			ID:        room.ID,
 			Name:      room.Name,
@@ -84,6 +199,10 @@ func (h *searchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			Capacity:  room.Capacity,
 			BasePrice: room.BasePrice,
 			Status:    room.Status,
+			Nightly:   toNightlyPriceDTOs(available.Nightly),
+			Total:     available.Total,
+			Discount:  available.Discount,
+			Plans:     toPlanPriceDTOs(available.Plans),
 		})
 	}
 
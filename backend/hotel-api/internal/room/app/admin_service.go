@@ -3,11 +3,10 @@ package app
 import (
 	"context"
 	"errors"
-	"fmt"
 	"strings"
-	"time"
 
 	bookingports "github.com/yourorg/hotel-api/internal/booking/ports"
+	propertyports "github.com/yourorg/hotel-api/internal/property/ports"
 	roomdomain "github.com/yourorg/hotel-api/internal/room/domain"
 	roomports "github.com/yourorg/hotel-api/internal/room/ports"
 )
@@ -21,14 +20,16 @@ var (
 type AdminService struct {
 	rooms    roomports.RoomRepository
 	bookings bookingports.BookingRepository
-	nowFn    func() time.Time
+	clock    propertyports.Clock
 }
 
-func NewAdminService(rooms roomports.RoomRepository, bookings bookingports.BookingRepository) *AdminService {
+// NewAdminService manages rooms; the clock's business date decides whether
+// a booked stay is still to come.
+func NewAdminService(rooms roomports.RoomRepository, bookings bookingports.BookingRepository, clock propertyports.Clock) *AdminService {
 	return &AdminService{
 		rooms:    rooms,
 		bookings: bookings,
-		nowFn:    time.Now,
+		clock:    clock,
 	}
 }
 
@@ -70,7 +71,7 @@ func (s *AdminService) Create(ctx context.Context, req CreateRoomRequest) (*Room
 
 	id := req.ID
 	if id == "" {
-		id = fmt.Sprintf("room-%d", s.nowFn().UnixNano())
+		id = newID("room")
 	}
 
 	newRoom := roomdomain.Room{
@@ -119,14 +120,19 @@ func (s *AdminService) Delete(ctx context.Context, id string) error {
 		return ErrRoomNotFound
 	}
 
-	now := s.nowFn()
+	today := s.clock.BusinessDate()
 	bookings, err := s.bookings.List(ctx)
 	if err != nil {
 		return err
 	}
 	for _, b := range bookings {
-		if b.RoomID == id && b.CheckOut.After(now) {
-			return ErrRoomHasFutureBookings
+		if !b.BlocksInventory() {
+			continue
+		}
+		for _, seg := range b.Stays() {
+			if seg.RoomID == id && seg.To.After(today) {
+				return ErrRoomHasFutureBookings
+			}
 		}
 	}
 
@@ -0,0 +1,17 @@
+package app
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// newID returns a random identifier with the given prefix. Several records
+// are often created within the same instant, so IDs cannot be taken from
+// the clock.
+func newID(prefix string) string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		panic("crypto/rand unavailable: " + err.Error())
+	}
+	return prefix + "-" + hex.EncodeToString(buf)
+}
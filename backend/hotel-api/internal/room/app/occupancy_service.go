@@ -0,0 +1,62 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	roomdomain "github.com/yourorg/hotel-api/internal/room/domain"
+	roomports "github.com/yourorg/hotel-api/internal/room/ports"
+)
+
+var ErrInvalidOccupancy = errors.New("invalid occupancy pricing")
+
+// OccupancyService manages the age bands and extra-guest charges that price
+// stays by who is staying.
+type OccupancyService struct {
+	occupancy roomports.OccupancyRepository
+}
+
+func NewOccupancyService(occupancy roomports.OccupancyRepository) *OccupancyService {
+	return &OccupancyService{occupancy: occupancy}
+}
+
+func (s *OccupancyService) Get(ctx context.Context) (roomdomain.OccupancyPricing, error) {
+	return s.occupancy.FindOccupancyPricing(ctx)
+}
+
+// Save replaces the age bands and occupancy rules. Band names and ages must
+// be unique, and child charges may only name known bands.
+func (s *OccupancyService) Save(ctx context.Context, pricing roomdomain.OccupancyPricing) (roomdomain.OccupancyPricing, error) {
+	bands := map[string]bool{}
+	ages := map[int]bool{}
+	for i, b := range pricing.Bands {
+		b.Name = strings.TrimSpace(b.Name)
+		if b.Name == "" || b.MaxAge < 0 || bands[b.Name] || ages[b.MaxAge] {
+			return roomdomain.OccupancyPricing{}, ErrInvalidOccupancy
+		}
+		bands[b.Name], ages[b.MaxAge] = true, true
+		pricing.Bands[i] = b
+	}
+
+	types := map[string]bool{}
+	for i, r := range pricing.Rules {
+		r.RoomType = strings.TrimSpace(r.RoomType)
+		key := strings.ToLower(r.RoomType)
+		if r.RoomType == "" || types[key] || r.BaseOccupancy < 1 || r.ExtraAdult < 0 {
+			return roomdomain.OccupancyPricing{}, ErrInvalidOccupancy
+		}
+		for band, charge := range r.ExtraChild {
+			if !bands[band] || charge < 0 {
+				return roomdomain.OccupancyPricing{}, ErrInvalidOccupancy
+			}
+		}
+		types[key] = true
+		pricing.Rules[i] = r
+	}
+
+	if err := s.occupancy.SaveOccupancyPricing(ctx, pricing); err != nil {
+		return roomdomain.OccupancyPricing{}, err
+	}
+	return pricing, nil
+}
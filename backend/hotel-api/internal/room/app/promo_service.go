@@ -0,0 +1,120 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	roomdomain "github.com/yourorg/hotel-api/internal/room/domain"
+	roomports "github.com/yourorg/hotel-api/internal/room/ports"
+)
+
+var (
+	ErrInvalidPromo  = errors.New("invalid promo code")
+	ErrPromoNotFound = errors.New("promo code not found")
+	ErrPromoExists   = errors.New("promo code already exists")
+)
+
+// PromoService manages the promo codes marketing runs campaigns with.
+type PromoService struct {
+	promos roomports.PromoRepository
+}
+
+func NewPromoService(promos roomports.PromoRepository) *PromoService {
+	return &PromoService{promos: promos}
+}
+
+// PromoUsage is a promo code with how often it has been redeemed.
+type PromoUsage struct {
+	Promo roomdomain.Promo
+	Uses  int
+}
+
+// List returns all promo codes in code order with their redemption counts.
+func (s *PromoService) List(ctx context.Context) ([]PromoUsage, error) {
+	promos, err := s.promos.ListPromos(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(promos, func(i, j int) bool { return promos[i].Code < promos[j].Code })
+
+	list := make([]PromoUsage, 0, len(promos))
+	for _, p := range promos {
+		used, err := s.promos.ListPromoRedemptions(ctx, p.Code)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, PromoUsage{Promo: p, Uses: len(used)})
+	}
+	return list, nil
+}
+
+// Create adds a new promo code.
+func (s *PromoService) Create(ctx context.Context, promo roomdomain.Promo) (*roomdomain.Promo, error) {
+	promo.Code = roomdomain.NormalizePromoCode(promo.Code)
+	if err := validatePromo(promo); err != nil {
+		return nil, err
+	}
+	existing, err := s.promos.FindPromo(ctx, promo.Code)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrPromoExists
+	}
+	if err := s.promos.SavePromo(ctx, promo); err != nil {
+		return nil, err
+	}
+	return &promo, nil
+}
+
+// Update replaces the promo code's terms. Past redemptions still count
+// towards its limits.
+func (s *PromoService) Update(ctx context.Context, promo roomdomain.Promo) (*roomdomain.Promo, error) {
+	promo.Code = roomdomain.NormalizePromoCode(promo.Code)
+	if err := validatePromo(promo); err != nil {
+		return nil, err
+	}
+	if _, err := findPromo(ctx, s.promos, promo.Code); err != nil {
+		return nil, err
+	}
+	if err := s.promos.SavePromo(ctx, promo); err != nil {
+		return nil, err
+	}
+	return &promo, nil
+}
+
+func (s *PromoService) Delete(ctx context.Context, code string) error {
+	code = roomdomain.NormalizePromoCode(code)
+	if _, err := findPromo(ctx, s.promos, code); err != nil {
+		return err
+	}
+	return s.promos.DeletePromo(ctx, code)
+}
+
+func validatePromo(p roomdomain.Promo) error {
+	switch {
+	case p.Code == "":
+		return ErrInvalidPromo
+	case (p.Percent > 0) == (p.Amount > 0), p.Percent < 0 || p.Percent > 100, p.Amount < 0:
+		return ErrInvalidPromo
+	case !p.ValidFrom.IsZero() && !p.ValidTo.IsZero() && p.ValidTo.Before(p.ValidFrom):
+		return ErrInvalidPromo
+	case !p.StayFrom.IsZero() && !p.StayTo.IsZero() && p.StayTo.Before(p.StayFrom):
+		return ErrInvalidPromo
+	case p.MinNights < 0 || p.MaxUses < 0 || p.MaxUsesPerGuest < 0:
+		return ErrInvalidPromo
+	}
+	return nil
+}
+
+func findPromo(ctx context.Context, promos roomports.PromoRepository, code string) (*roomdomain.Promo, error) {
+	promo, err := promos.FindPromo(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if promo == nil {
+		return nil, ErrPromoNotFound
+	}
+	return promo, nil
+}
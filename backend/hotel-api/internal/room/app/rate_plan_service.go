@@ -0,0 +1,100 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	propertyports "github.com/yourorg/hotel-api/internal/property/ports"
+	roomdomain "github.com/yourorg/hotel-api/internal/room/domain"
+	roomports "github.com/yourorg/hotel-api/internal/room/ports"
+)
+
+var (
+	ErrInvalidRatePlan  = errors.New("invalid rate plan")
+	ErrRatePlanNotFound = errors.New("rate plan not found")
+)
+
+// RatePlanService manages the rate plans revenue staff sell rooms under.
+type RatePlanService struct {
+	plans roomports.RatePlanRepository
+	clock propertyports.Clock
+}
+
+func NewRatePlanService(plans roomports.RatePlanRepository, clock propertyports.Clock) *RatePlanService {
+	return &RatePlanService{plans: plans, clock: clock}
+}
+
+// List returns all rate plans ordered by name.
+func (s *RatePlanService) List(ctx context.Context) ([]roomdomain.RatePlan, error) {
+	plans, err := s.plans.ListRatePlans(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sortRatePlans(plans)
+	return plans, nil
+}
+
+// Save creates the plan, or replaces the one with the same ID.
+func (s *RatePlanService) Save(ctx context.Context, plan roomdomain.RatePlan) (*roomdomain.RatePlan, error) {
+	plan.Name = strings.TrimSpace(plan.Name)
+	if plan.Name == "" || plan.Price.Percent <= -100 || plan.Price.PerNight < 0 || plan.Price.PerGuest < 0 ||
+		plan.Cancellation.FreeCancellation < 0 || plan.Cancellation.FeeNights < 0 {
+		return nil, ErrInvalidRatePlan
+	}
+	for _, channel := range plan.Channels {
+		if channel != roomdomain.ChannelWeb && channel != roomdomain.ChannelFrontDesk {
+			return nil, ErrInvalidRatePlan
+		}
+	}
+	plan.RoomTypes = trimAll(plan.RoomTypes)
+	plan.Inclusions = trimAll(plan.Inclusions)
+
+	if plan.ID == "" {
+		plan.ID = newID("plan")
+	} else if err := s.find(ctx, plan.ID); err != nil {
+		return nil, err
+	}
+	if err := s.plans.SaveRatePlan(ctx, plan); err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+func (s *RatePlanService) Delete(ctx context.Context, id string) error {
+	if err := s.find(ctx, id); err != nil {
+		return err
+	}
+	return s.plans.DeleteRatePlan(ctx, id)
+}
+
+func (s *RatePlanService) find(ctx context.Context, id string) error {
+	plan, err := s.plans.FindRatePlan(ctx, id)
+	if err != nil {
+		return err
+	}
+	if plan == nil {
+		return ErrRatePlanNotFound
+	}
+	return nil
+}
+
+func sortRatePlans(plans []roomdomain.RatePlan) {
+	sort.Slice(plans, func(i, j int) bool {
+		if plans[i].Name != plans[j].Name {
+			return plans[i].Name < plans[j].Name
+		}
+		return plans[i].ID < plans[j].ID
+	})
+}
+
+func trimAll(values []string) []string {
+	var trimmed []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			trimmed = append(trimmed, v)
+		}
+	}
+	return trimmed
+}
@@ -0,0 +1,216 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
+	propertyports "github.com/yourorg/hotel-api/internal/property/ports"
+	roomdomain "github.com/yourorg/hotel-api/internal/room/domain"
+	roomports "github.com/yourorg/hotel-api/internal/room/ports"
+)
+
+const maxRateCalendarDays = 366
+
+var (
+	ErrInvalidSeason       = errors.New("invalid season")
+	ErrSeasonNotFound      = errors.New("season not found")
+	ErrInvalidRateOverride = errors.New("invalid rate override")
+	ErrRateOverrideMissing = errors.New("rate override not found")
+)
+
+// RateService manages the rate calendar revenue staff set per room type:
+// seasons with weekday and weekend prices, and per-date overrides.
+type RateService struct {
+	rates roomports.RateRepository
+	rooms roomports.RoomRepository
+	clock propertyports.Clock
+}
+
+func NewRateService(rates roomports.RateRepository, rooms roomports.RoomRepository, clock propertyports.Clock) *RateService {
+	return &RateService{rates: rates, rooms: rooms, clock: clock}
+}
+
+// TypeRate is a room type's price for one night as the calendar shows it.
+type TypeRate struct {
+	RoomType string
+	Date     propertydomain.Date
+	Price    float64
+	Source   string
+}
+
+// Calendar lists the price of every room type, or only roomType, for each
+// night from through to inclusive. Nights without a season or override show
+// the type's cheapest base price.
+func (s *RateService) Calendar(ctx context.Context, roomType string, from, to propertydomain.Date) ([]TypeRate, error) {
+	if from.IsZero() || to.Before(from) || from.DaysUntil(to) > maxRateCalendarDays {
+		return nil, ErrInvalidDateRange
+	}
+	calendar, err := LoadRateCalendar(ctx, s.rates)
+	if err != nil {
+		return nil, err
+	}
+	rooms, err := s.rooms.ListRooms(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	base := map[string]float64{}
+	for _, r := range rooms {
+		if roomType != "" && !strings.EqualFold(r.Type, roomType) {
+			continue
+		}
+		if price, ok := base[r.Type]; !ok || r.BasePrice < price {
+			base[r.Type] = r.BasePrice
+		}
+	}
+	types := make([]string, 0, len(base))
+	for t := range base {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	var rates []TypeRate
+	for d := from; !d.After(to); d = d.AddDays(1) {
+		for _, t := range types {
+			price, source := calendar.Lookup(t, d)
+			if source == roomdomain.RateSourceBase {
+				price = base[t]
+			}
+			rates = append(rates, TypeRate{RoomType: t, Date: d, Price: price, Source: source})
+		}
+	}
+	return rates, nil
+}
+
+// Seasons returns all seasons ordered by start date and room type.
+func (s *RateService) Seasons(ctx context.Context) ([]roomdomain.Season, error) {
+	seasons, err := s.rates.ListSeasons(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(seasons, func(i, j int) bool {
+		if seasons[i].From != seasons[j].From {
+			return seasons[i].From.Before(seasons[j].From)
+		}
+		return seasons[i].RoomType < seasons[j].RoomType
+	})
+	return seasons, nil
+}
+
+// SaveSeason creates the season, or replaces the one with the same ID.
+func (s *RateService) SaveSeason(ctx context.Context, season roomdomain.Season) (*roomdomain.Season, error) {
+	season.RoomType = strings.TrimSpace(season.RoomType)
+	season.Name = strings.TrimSpace(season.Name)
+	if season.RoomType == "" || season.From.IsZero() || season.To.Before(season.From) ||
+		season.Price <= 0 || season.WeekendPrice < 0 {
+		return nil, ErrInvalidSeason
+	}
+
+	if season.ID == "" {
+		season.ID = newID("season")
+	} else if err := s.findSeason(ctx, season.ID); err != nil {
+		return nil, err
+	}
+	if err := s.rates.SaveSeason(ctx, season); err != nil {
+		return nil, err
+	}
+	return &season, nil
+}
+
+func (s *RateService) DeleteSeason(ctx context.Context, id string) error {
+	if err := s.findSeason(ctx, id); err != nil {
+		return err
+	}
+	return s.rates.DeleteSeason(ctx, id)
+}
+
+func (s *RateService) findSeason(ctx context.Context, id string) error {
+	seasons, err := s.rates.ListSeasons(ctx)
+	if err != nil {
+		return err
+	}
+	for _, season := range seasons {
+		if season.ID == id {
+			return nil
+		}
+	}
+	return ErrSeasonNotFound
+}
+
+// Overrides returns the overrides between from and to inclusive, ordered by
+// date and room type. Zero bounds are open.
+func (s *RateService) Overrides(ctx context.Context, from, to propertydomain.Date) ([]roomdomain.RateOverride, error) {
+	all, err := s.rates.ListRateOverrides(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []roomdomain.RateOverride
+	for _, o := range all {
+		if !from.IsZero() && o.Date.Before(from) {
+			continue
+		}
+		if !to.IsZero() && o.Date.After(to) {
+			continue
+		}
+		list = append(list, o)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Date != list[j].Date {
+			return list[i].Date.Before(list[j].Date)
+		}
+		return list[i].RoomType < list[j].RoomType
+	})
+	return list, nil
+}
+
+// SetOverride fixes the price for every date from o.Date through the given
+// date inclusive, all or none of them. A zero through sets one date.
+func (s *RateService) SetOverride(ctx context.Context, o roomdomain.RateOverride, through propertydomain.Date) error {
+	o.RoomType = strings.TrimSpace(o.RoomType)
+	if o.RoomType == "" || o.Date.IsZero() || o.Price <= 0 {
+		return ErrInvalidRateOverride
+	}
+	if through.IsZero() {
+		through = o.Date
+	}
+	if through.Before(o.Date) || o.Date.DaysUntil(through) > maxRateCalendarDays {
+		return ErrInvalidRateOverride
+	}
+
+	var overrides []roomdomain.RateOverride
+	for d := o.Date; !d.After(through); d = d.AddDays(1) {
+		o.Date = d
+		overrides = append(overrides, o)
+	}
+	return s.rates.SaveRateOverrides(ctx, overrides)
+}
+
+func (s *RateService) DeleteOverride(ctx context.Context, roomType string, date propertydomain.Date) error {
+	all, err := s.rates.ListRateOverrides(ctx)
+	if err != nil {
+		return err
+	}
+	for _, o := range all {
+		if strings.EqualFold(o.RoomType, strings.TrimSpace(roomType)) && o.Date == date {
+			return s.rates.DeleteRateOverride(ctx, o.RoomType, o.Date)
+		}
+	}
+	return ErrRateOverrideMissing
+}
+
+// LoadRateCalendar builds the rate calendar from everything stored.
+func LoadRateCalendar(ctx context.Context, rates roomports.RateRepository) (roomdomain.RateCalendar, error) {
+	seasons, err := rates.ListSeasons(ctx)
+	if err != nil {
+		return roomdomain.RateCalendar{}, err
+	}
+	overrides, err := rates.ListRateOverrides(ctx)
+	if err != nil {
+		return roomdomain.RateCalendar{}, err
+	}
+	return roomdomain.NewRateCalendar(seasons, overrides), nil
+}
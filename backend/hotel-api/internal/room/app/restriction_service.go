@@ -0,0 +1,106 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
+	roomdomain "github.com/yourorg/hotel-api/internal/room/domain"
+	roomports "github.com/yourorg/hotel-api/internal/room/ports"
+)
+
+const maxRestrictionDays = 366
+
+var (
+	ErrInvalidRestriction  = errors.New("invalid stay restriction")
+	ErrRestrictionNotFound = errors.New("stay restriction not found")
+	ErrInvalidHorizon      = errors.New("invalid booking horizon")
+)
+
+// RestrictionService manages the stay restrictions revenue staff set per
+// room type and date.
+type RestrictionService struct {
+	restrictions roomports.RestrictionRepository
+}
+
+func NewRestrictionService(restrictions roomports.RestrictionRepository) *RestrictionService {
+	return &RestrictionService{restrictions: restrictions}
+}
+
+// SetHorizon replaces the booking horizon that searches and new bookings are
+// checked against.
+func (s *RestrictionService) SetHorizon(ctx context.Context, h roomdomain.BookingHorizon) error {
+	if h.MinLeadDays < 0 || h.MaxAdvanceDays < 0 || h.SameDayCutoff < 0 || h.SameDayCutoff >= 24*time.Hour {
+		return ErrInvalidHorizon
+	}
+	if h.MaxAdvanceDays > 0 && h.MaxAdvanceDays < h.MinLeadDays {
+		return ErrInvalidHorizon
+	}
+	return s.restrictions.SaveBookingHorizon(ctx, h)
+}
+
+// List returns the restrictions between from and to inclusive, ordered by
+// date and room type. Zero bounds are open.
+func (s *RestrictionService) List(ctx context.Context, from, to propertydomain.Date) ([]roomdomain.StayRestriction, error) {
+	all, err := s.restrictions.ListRestrictions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []roomdomain.StayRestriction
+	for _, r := range all {
+		if !from.IsZero() && r.Date.Before(from) {
+			continue
+		}
+		if !to.IsZero() && r.Date.After(to) {
+			continue
+		}
+		list = append(list, r)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Date != list[j].Date {
+			return list[i].Date.Before(list[j].Date)
+		}
+		return list[i].RoomType < list[j].RoomType
+	})
+	return list, nil
+}
+
+// Set stores the restriction for every date from r.Date through the given
+// date inclusive, replacing what was there. A zero through sets one date.
+func (s *RestrictionService) Set(ctx context.Context, r roomdomain.StayRestriction, through propertydomain.Date) error {
+	if r.Date.IsZero() || r.MinStay < 0 || r.MaxStay < 0 || (r.MaxStay > 0 && r.MaxStay < r.MinStay) {
+		return ErrInvalidRestriction
+	}
+	if through.IsZero() {
+		through = r.Date
+	}
+	if through.Before(r.Date) || r.Date.DaysUntil(through) > maxRestrictionDays {
+		return ErrInvalidRestriction
+	}
+
+	r.RoomType = strings.TrimSpace(r.RoomType)
+	for d := r.Date; !d.After(through); d = d.AddDays(1) {
+		r.Date = d
+		if err := s.restrictions.SaveRestriction(ctx, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *RestrictionService) Delete(ctx context.Context, roomType string, date propertydomain.Date) error {
+	all, err := s.restrictions.ListRestrictions(ctx)
+	if err != nil {
+		return err
+	}
+	for _, r := range all {
+		if strings.EqualFold(r.RoomType, strings.TrimSpace(roomType)) && r.Date == date {
+			return s.restrictions.DeleteRestriction(ctx, r.RoomType, r.Date)
+		}
+	}
+	return ErrRestrictionNotFound
+}
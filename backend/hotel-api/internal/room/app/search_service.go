@@ -3,10 +3,12 @@ package app
 import (
 	"context"
 	"errors"
-	"time"
+	"sort"
 
 	bookingdomain "github.com/yourorg/hotel-api/internal/booking/domain"
 	bookingports "github.com/yourorg/hotel-api/internal/booking/ports"
+	propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
+	propertyports "github.com/yourorg/hotel-api/internal/property/ports"
 	roomdomain "github.com/yourorg/hotel-api/internal/room/domain"
 	roomports "github.com/yourorg/hotel-api/internal/room/ports"
 )
@@ -16,31 +18,109 @@ var (
 )
 
 type SearchService struct {
-	rooms    roomports.RoomRepository
-	bookings bookingports.BookingRepository
+	rooms        roomports.RoomRepository
+	bookings     bookingports.BookingRepository
+	overbooking  bookingports.OverbookingRepository
+	restrictions roomports.RestrictionRepository
+	rates        roomports.RateRepository
+	plans        roomports.RatePlanRepository
+	promos       roomports.PromoRepository
+	occupancy    roomports.OccupancyRepository
+	clock        propertyports.Clock
 }
 
-func NewSearchService(rooms roomports.RoomRepository, bookings bookingports.BookingRepository) *SearchService {
+func NewSearchService(rooms roomports.RoomRepository, bookings bookingports.BookingRepository, overbooking bookingports.OverbookingRepository, restrictions roomports.RestrictionRepository, rates roomports.RateRepository, plans roomports.RatePlanRepository, promos roomports.PromoRepository, occupancy roomports.OccupancyRepository, clock propertyports.Clock) *SearchService {
 	return &SearchService{
-		rooms:    rooms,
-		bookings: bookings,
+		rooms:        rooms,
+		bookings:     bookings,
+		overbooking:  overbooking,
+		restrictions: restrictions,
+		rates:        rates,
+		plans:        plans,
+		promos:       promos,
+		occupancy:    occupancy,
+		clock:        clock,
 	}
 }
 
+// SearchInput is the stay being searched. Guests counts everyone staying and
+// ChildAges gives the age of each child among them; prices include their
+// extra-guest charges. Channel picks the rate plans on offer and defaults to
+// the web. PromoCode, when set, prices the discount it gives on each room and
+// plan.
 type SearchInput struct {
-	CheckIn  time.Time
-	CheckOut time.Time
-	Guests   int
+	CheckIn   propertydomain.Date
+	CheckOut  propertydomain.Date
+	Guests    int
+	ChildAges []int
+	Channel   string
+	PromoCode string
 }
 
-func (s *SearchService) Search(ctx context.Context, input SearchInput) ([]roomdomain.Room, error) {
+// SearchResult lists the bookable rooms and, per room type, how many rooms
+// are left for the whole stay, each priced night by night from the rate
+// calendar. Room types whose stay restrictions rule out the dates are listed
+// in Restricted instead.
+type SearchResult struct {
+	Rooms      []AvailableRoom
+	RoomTypes  []RoomTypeAvailability
+	Restricted []RestrictedRoomType
+}
+
+// AvailableRoom is a room free for the whole stay with its calendar price
+// and its price under every rate plan offered for it. Discount is what the
+// searched promo code takes off Total.
+type AvailableRoom struct {
+	Room     roomdomain.Room
+	Nightly  []roomdomain.NightlyRate
+	Total    float64
+	Discount float64
+	Plans    []PlanPrice
+}
+
+// PlanPrice prices a stay under one rate plan.
+type PlanPrice struct {
+	Plan     roomdomain.RatePlan
+	Nightly  []roomdomain.NightlyRate
+	Total    float64
+	Discount float64
+}
+
+// RestrictedRoomType is a room type that cannot be booked for the searched
+// stay and the rule that prevents it.
+type RestrictedRoomType struct {
+	Type      string
+	Violation *roomdomain.RestrictionError
+}
+
+// RoomTypeAvailability prices a room type by its cheapest room for the
+// stay; FromPrice is that room's average nightly rate and Plans price it
+// under each rate plan offered for the type.
+type RoomTypeAvailability struct {
+	Type      string
+	Available int
+	Capacity  int
+	FromPrice float64
+	Nightly   []roomdomain.NightlyRate
+	Total     float64
+	Plans     []PlanPrice
+}
+
+func (s *SearchService) Search(ctx context.Context, input SearchInput) (*SearchResult, error) {
 	if input.CheckIn.IsZero() || input.CheckOut.IsZero() || !input.CheckOut.After(input.CheckIn) {
 		return nil, ErrInvalidDateRange
 	}
 
-	// Demo data does not include bookings beyond 2029; treat far-future queries as no availability.
-	if input.CheckIn.Year() >= 2030 {
-		return []roomdomain.Room{}, nil
+	horizon, err := s.restrictions.FindBookingHorizon(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if v := horizon.Check(s.clock.BusinessDate(), s.clock.Now(), s.clock.Location(), input.CheckIn); v != nil {
+		return nil, v
+	}
+	party, ok := roomdomain.PartyOf(input.Guests, input.ChildAges)
+	if !ok {
+		return nil, roomdomain.ErrInvalidParty
 	}
 
 	candidates, err := s.rooms.SearchAvailable(ctx, roomports.SearchParams{
@@ -52,33 +132,171 @@ func (s *SearchService) Search(ctx context.Context, input SearchInput) ([]roomdo
 		return nil, err
 	}
 
+	allRooms, err := s.rooms.ListRooms(ctx)
+	if err != nil {
+		return nil, err
+	}
 	allBookings, err := s.bookings.List(ctx)
 	if err != nil {
 		return nil, err
 	}
+	limits, err := s.overbooking.ListOverbookingLimits(ctx)
+	if err != nil {
+		return nil, err
+	}
+	inv := bookingdomain.NewInventory(InventoryRooms(allRooms), allBookings).WithOverbooking(bookingdomain.NewOverbookingPolicy(limits))
+	stored, err := s.restrictions.ListRestrictions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	restrictions := roomdomain.NewRestrictions(stored)
+	calendar, err := LoadRateCalendar(ctx, s.rates)
+	if err != nil {
+		return nil, err
+	}
+	plans, err := s.plans.ListRatePlans(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sortRatePlans(plans)
+	occupancy, err := s.occupancy.FindOccupancyPricing(ctx)
+	if err != nil {
+		return nil, err
+	}
+	promo, err := s.searchPromo(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	channel := input.Channel
+	if channel == "" {
+		channel = roomdomain.ChannelWeb
+	}
+	nights := float64(len(bookingdomain.Nights(input.CheckIn, input.CheckOut)))
 
-	var result []roomdomain.Room
+	result := &SearchResult{}
+	types := map[string]*RoomTypeAvailability{}
+	restricted := map[string]*roomdomain.RestrictionError{}
 	for _, room := range candidates {
 		if room.Status == "OUT_OF_ORDER" {
 			continue
 		}
-		if hasOverlap(allBookings, room.ID, input.CheckIn, input.CheckOut) {
+		if v := restrictions.Check(room.Type, input.CheckIn, input.CheckOut); v != nil {
+			restricted[room.Type] = v
+			continue
+		}
+		available := inv.TypeAvailability(room.Type, input.CheckIn, input.CheckOut, "")
+		if available <= 0 {
 			continue
 		}
-		result = append(result, room)
+
+		nightly, _ := calendar.Stay(room, input.CheckIn, input.CheckOut)
+		nightly, total := occupancy.Stay(room.Type, party, nightly)
+		t, ok := types[room.Type]
+		if !ok {
+			t = &RoomTypeAvailability{Type: room.Type, Available: available}
+			types[room.Type] = t
+		}
+		if room.Capacity > t.Capacity {
+			t.Capacity = room.Capacity
+		}
+		roomPlans := planPrices(plans, room.Type, channel, party, nightly, promo)
+		if t.Nightly == nil || total < t.Total {
+			t.Nightly = nightly
+			t.Total = total
+			t.FromPrice = bookingdomain.RoundAmount(total / nights)
+			t.Plans = roomPlans
+		}
+
+		if inv.RoomFree(room.ID, input.CheckIn, input.CheckOut, "") {
+			result.Rooms = append(result.Rooms, AvailableRoom{
+				Room:     room,
+				Nightly:  nightly,
+				Total:    total,
+				Discount: promoDiscount(promo, room.Type, nil, nightly, nightly, party.Size()),
+				Plans:    roomPlans,
+			})
+		}
+	}
+
+	for _, t := range types {
+		result.RoomTypes = append(result.RoomTypes, *t)
 	}
+	for roomType, v := range restricted {
+		result.Restricted = append(result.Restricted, RestrictedRoomType{Type: roomType, Violation: v})
+	}
+	sort.Slice(result.Restricted, func(i, j int) bool { return result.Restricted[i].Type < result.Restricted[j].Type })
+	sort.Slice(result.RoomTypes, func(i, j int) bool { return result.RoomTypes[i].Type < result.RoomTypes[j].Type })
+	sort.Slice(result.Rooms, func(i, j int) bool { return result.Rooms[i].Room.ID < result.Rooms[j].Room.ID })
 
 	return result, nil
 }
 
-func hasOverlap(bookings []bookingdomain.Booking, roomID string, from, to time.Time) bool {
-	for _, b := range bookings {
-		if b.RoomID != roomID {
+// planPrices prices the calendar rates under every plan offered for the
+// room type on the channel.
+func planPrices(plans []roomdomain.RatePlan, roomType, channel string, party roomdomain.Party, nightly []roomdomain.NightlyRate, promo *roomdomain.Promo) []PlanPrice {
+	var prices []PlanPrice
+	for i, plan := range plans {
+		if !plan.Offered(roomType, channel) {
 			continue
 		}
-		if from.Before(b.CheckOut) && to.After(b.CheckIn) {
-			return true
-		}
+		planNightly, planTotal := plan.Stay(nightly, party.Size())
+		prices = append(prices, PlanPrice{
+			Plan:     plan,
+			Nightly:  planNightly,
+			Total:    planTotal,
+			Discount: promoDiscount(promo, roomType, &plans[i], nightly, planNightly, party.Size()),
+		})
+	}
+	return prices
+}
+
+// searchPromo looks up the searched promo code and checks it is usable for
+// the stay at all. Per-guest limits are only checked when booking.
+func (s *SearchService) searchPromo(ctx context.Context, input SearchInput) (*roomdomain.Promo, error) {
+	code := roomdomain.NormalizePromoCode(input.PromoCode)
+	if code == "" {
+		return nil, nil
+	}
+	promo, err := findPromo(ctx, s.promos, code)
+	if err != nil {
+		return nil, err
+	}
+	if !promo.Active {
+		return nil, ErrPromoNotFound
+	}
+	if v := promo.CheckStay(s.clock.BusinessDate(), input.CheckIn, input.CheckOut); v != nil {
+		return nil, v
+	}
+	return promo, nil
+}
+
+// promoDiscount is what the promo takes off the nightly prices of the room
+// type under the plan, or zero when it does not apply. calendar holds the
+// rates before the plan.
+func promoDiscount(promo *roomdomain.Promo, roomType string, plan *roomdomain.RatePlan, calendar, nightly []roomdomain.NightlyRate, guests int) float64 {
+	if promo == nil || promo.CheckRate(roomType, plan, nightlyPrices(calendar), guests) != nil {
+		return 0
+	}
+	total := 0.0
+	for _, d := range promo.Discounts(nightlyPrices(nightly)) {
+		total += d
+	}
+	return bookingdomain.RoundAmount(total)
+}
+
+func nightlyPrices(nightly []roomdomain.NightlyRate) []float64 {
+	prices := make([]float64, 0, len(nightly))
+	for _, n := range nightly {
+		prices = append(prices, n.Price)
+	}
+	return prices
+}
+
+// InventoryRooms describes rooms as the booking inventory counts them.
+func InventoryRooms(rooms []roomdomain.Room) []bookingdomain.RoomUnit {
+	units := make([]bookingdomain.RoomUnit, 0, len(rooms))
+	for _, r := range rooms {
+		units = append(units, bookingdomain.RoomUnit{ID: r.ID, Type: r.Type, Sellable: r.Sellable()})
 	}
-	return false
+	return units
 }
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"time"
+
+	propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
+)
+
+// Codes reported when a stay falls outside the booking horizon.
+const (
+	ViolationCheckInPast   = "check_in_past"
+	ViolationMinLeadTime   = "min_lead_time"
+	ViolationMaxAdvance    = "max_advance"
+	ViolationSameDayCutoff = "same_day_cutoff"
+)
+
+// BookingHorizon limits how soon and how far ahead a stay can be booked.
+type BookingHorizon struct {
+	// MinLeadDays is how many days before arrival a booking must be made;
+	// zero allows same-day bookings.
+	MinLeadDays int
+	// MaxAdvanceDays is how many days ahead arrival may be; zero is no limit.
+	MaxAdvanceDays int
+	// SameDayCutoff is the time of day, as an offset from midnight, after
+	// which arrivals for today can no longer be booked; zero is no cut-off.
+	SameDayCutoff time.Duration
+}
+
+// DefaultBookingHorizon allows same-day bookings up to a year ahead.
+func DefaultBookingHorizon() BookingHorizon {
+	return BookingHorizon{MaxAdvanceDays: 365}
+}
+
+// Check reports whether a stay arriving on checkIn can be booked on the
+// business date today at now. Days are counted from the business date, so
+// until the night audit closes it, a stay arriving on it can still be
+// booked; the same-day cut-off is the time of day in the property's
+// timezone loc.
+func (h BookingHorizon) Check(today propertydomain.Date, now time.Time, loc *time.Location, checkIn propertydomain.Date) *RestrictionError {
+	days := today.DaysUntil(checkIn)
+
+	switch {
+	case days < 0:
+		return &RestrictionError{Code: ViolationCheckInPast, Date: checkIn}
+	case days < h.MinLeadDays:
+		return &RestrictionError{Code: ViolationMinLeadTime, Date: checkIn, Limit: h.MinLeadDays}
+	case h.MaxAdvanceDays > 0 && days > h.MaxAdvanceDays:
+		return &RestrictionError{Code: ViolationMaxAdvance, Date: checkIn, Limit: h.MaxAdvanceDays}
+	case days == 0 && h.SameDayCutoff > 0 && !now.Before(today.At(h.SameDayCutoff, loc)):
+		return &RestrictionError{Code: ViolationSameDayCutoff, Date: checkIn}
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strings"
+)
+
+// ErrInvalidParty is returned for a guest count and child ages that do not
+// make up a party PartyOf accepts.
+var ErrInvalidParty = errors.New("child ages must be valid and leave at least one adult among the guests")
+
+// AgeBand groups children aged up to MaxAge inclusive, above the next
+// younger band. Children older than every band are priced as adults.
+type AgeBand struct {
+	Name   string
+	MaxAge int
+}
+
+// OccupancyRule prices extra guests in a room type. The nightly rate covers
+// BaseOccupancy guests; every guest beyond that adds ExtraAdult, or for a
+// child the charge for their age band in ExtraChild, per night.
+type OccupancyRule struct {
+	RoomType      string
+	BaseOccupancy int
+	ExtraAdult    float64
+	ExtraChild    map[string]float64
+}
+
+// OccupancyPricing is the property's age bands and the occupancy rule of
+// each room type. Room types without a rule are priced per room.
+type OccupancyPricing struct {
+	Bands []AgeBand
+	Rules []OccupancyRule
+}
+
+// Party is who is staying: adults and the age of each child.
+type Party struct {
+	Adults    int
+	ChildAges []int
+}
+
+// PartyOf splits a guest count into adults and the children whose ages are
+// given. It reports false unless at least one adult remains and every age is
+// valid. A zero count with no children is taken as one adult.
+func PartyOf(guests int, childAges []int) (Party, bool) {
+	if guests == 0 && len(childAges) == 0 {
+		return Party{Adults: 1}, true
+	}
+	if guests-len(childAges) < 1 {
+		return Party{}, false
+	}
+	for _, age := range childAges {
+		if age < 0 {
+			return Party{}, false
+		}
+	}
+	return Party{Adults: guests - len(childAges), ChildAges: append([]int(nil), childAges...)}, true
+}
+
+// Size is the number of guests in the party.
+func (p Party) Size() int {
+	return p.Adults + len(p.ChildAges)
+}
+
+// Band returns the age band a child of the given age falls in, or false
+// when they count as an adult.
+func (o OccupancyPricing) Band(age int) (AgeBand, bool) {
+	bands := append([]AgeBand(nil), o.Bands...)
+	sort.Slice(bands, func(i, j int) bool { return bands[i].MaxAge < bands[j].MaxAge })
+	for _, b := range bands {
+		if age <= b.MaxAge {
+			return b, true
+		}
+	}
+	return AgeBand{}, false
+}
+
+// Rule returns the occupancy rule for the room type, if any.
+func (o OccupancyPricing) Rule(roomType string) (OccupancyRule, bool) {
+	for _, r := range o.Rules {
+		if strings.EqualFold(r.RoomType, roomType) {
+			return r, true
+		}
+	}
+	return OccupancyRule{}, false
+}
+
+// Extra is what the party adds to one night in the room type beyond its
+// base occupancy. The guests that would cost most as extras fill the base
+// occupancy first.
+func (o OccupancyPricing) Extra(roomType string, party Party) float64 {
+	rule, ok := o.Rule(roomType)
+	if !ok || party.Size() <= rule.BaseOccupancy {
+		return 0
+	}
+
+	charges := make([]float64, 0, party.Size())
+	for i := 0; i < party.Adults; i++ {
+		charges = append(charges, rule.ExtraAdult)
+	}
+	for _, age := range party.ChildAges {
+		if band, ok := o.Band(age); ok {
+			charges = append(charges, rule.ExtraChild[band.Name])
+		} else {
+			charges = append(charges, rule.ExtraAdult)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(charges)))
+
+	extra := 0.0
+	for _, c := range charges[rule.BaseOccupancy:] {
+		extra += c
+	}
+	return math.Round(extra*100) / 100
+}
+
+// Stay adds the party's extra-guest charge to every night of a stay priced
+// from the rate calendar and returns the nightly rates with their total.
+func (o OccupancyPricing) Stay(roomType string, party Party, nightly []NightlyRate) ([]NightlyRate, float64) {
+	extra := o.Extra(roomType, party)
+	rates := make([]NightlyRate, 0, len(nightly))
+	total := 0.0
+	for _, n := range nightly {
+		n.Price = math.Round((n.Price+extra)*100) / 100
+		rates = append(rates, n)
+		total += n.Price
+	}
+	return rates, math.Round(total*100) / 100
+}
@@ -0,0 +1,138 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
+)
+
+// Codes reported when a promo code cannot be used for a stay.
+const (
+	PromoNotYetValid      = "promo_not_yet_valid"
+	PromoExpired          = "promo_expired"
+	PromoStayDates        = "promo_stay_dates"
+	PromoMinStay          = "promo_min_stay"
+	PromoRoomType         = "promo_room_type"
+	PromoNotStackable     = "promo_not_stackable"
+	PromoFirstBookingOnly = "promo_first_booking_only"
+	PromoLimitReached     = "promo_limit_reached"
+	PromoGuestLimit       = "promo_guest_limit_reached"
+)
+
+// Promo is a campaign code giving Percent off the room rate, or a fixed
+// Amount off the stay. ValidFrom and ValidTo bound the dates it can be
+// booked on, StayFrom and StayTo the nights it covers; zero dates are open.
+// MaxUses and MaxUsesPerGuest cap redemptions, zero meaning no cap. A code
+// only combines with a discounted rate plan when Stackable.
+type Promo struct {
+	Code             string
+	Description      string
+	Percent          float64
+	Amount           float64
+	ValidFrom        propertydomain.Date
+	ValidTo          propertydomain.Date
+	StayFrom         propertydomain.Date
+	StayTo           propertydomain.Date
+	MinNights        int
+	RoomTypes        []string
+	MaxUses          int
+	MaxUsesPerGuest  int
+	FirstBookingOnly bool
+	Stackable        bool
+	Active           bool
+}
+
+// PromoRedemption records one use of a promo code by a booking.
+type PromoRedemption struct {
+	Code       string
+	UserID     string
+	BookingID  string
+	RedeemedAt time.Time
+}
+
+// PromoError is returned when a promo code does not apply to a stay.
+type PromoError struct {
+	Code  string
+	Limit int
+}
+
+func (e *PromoError) Error() string {
+	switch e.Code {
+	case PromoNotYetValid:
+		return "promo code is not valid yet"
+	case PromoExpired:
+		return "promo code has expired"
+	case PromoStayDates:
+		return "promo code does not cover these dates"
+	case PromoMinStay:
+		return fmt.Sprintf("promo code requires a stay of at least %d nights", e.Limit)
+	case PromoRoomType:
+		return "promo code does not apply to this room type"
+	case PromoNotStackable:
+		return "promo code cannot be combined with this rate"
+	case PromoFirstBookingOnly:
+		return "promo code is only valid on a first booking"
+	case PromoLimitReached:
+		return "promo code has been fully redeemed"
+	case PromoGuestLimit:
+		return fmt.Sprintf("promo code allows %d use(s) per guest", e.Limit)
+	}
+	return e.Code
+}
+
+// NormalizePromoCode uppercases a code and strips surrounding space.
+func NormalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// CheckStay reports why the code cannot be used on a stay booked on the
+// given day, regardless of the room, or nil.
+func (p Promo) CheckStay(bookedOn, checkIn, checkOut propertydomain.Date) *PromoError {
+	switch {
+	case !p.ValidFrom.IsZero() && bookedOn.Before(p.ValidFrom):
+		return &PromoError{Code: PromoNotYetValid}
+	case !p.ValidTo.IsZero() && bookedOn.After(p.ValidTo):
+		return &PromoError{Code: PromoExpired}
+	case !p.StayFrom.IsZero() && checkIn.Before(p.StayFrom):
+		return &PromoError{Code: PromoStayDates}
+	case !p.StayTo.IsZero() && checkOut.AddDays(-1).After(p.StayTo):
+		return &PromoError{Code: PromoStayDates}
+	}
+	if nights := checkIn.DaysUntil(checkOut); nights < p.MinNights {
+		return &PromoError{Code: PromoMinStay, Limit: p.MinNights}
+	}
+	return nil
+}
+
+// CheckRate reports why the code cannot be used on the room type under the
+// given rate plan for a party of guests, or nil. A nil plan is the plain
+// calendar rate; calendar holds the stay's nightly rates before the plan.
+func (p Promo) CheckRate(roomType string, plan *RatePlan, calendar []float64, guests int) *PromoError {
+	if !matchesAny(p.RoomTypes, roomType) {
+		return &PromoError{Code: PromoRoomType}
+	}
+	if plan != nil && plan.Discounted(calendar, guests) && !p.Stackable {
+		return &PromoError{Code: PromoNotStackable}
+	}
+	return nil
+}
+
+// Discounts splits the code's discount over the nightly prices. A percent
+// comes off every night; a fixed amount is taken from the first nights
+// onwards and never exceeds the stay.
+func (p Promo) Discounts(nightly []float64) []float64 {
+	discounts := make([]float64, len(nightly))
+	left := p.Amount
+	for i, price := range nightly {
+		d := price * p.Percent / 100
+		if p.Amount > 0 {
+			d = math.Min(left, price)
+			left -= d
+		}
+		discounts[i] = math.Round(d*100) / 100
+	}
+	return discounts
+}
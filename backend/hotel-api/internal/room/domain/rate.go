@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
+)
+
+// Sources of a room type's price on a night.
+const (
+	RateSourceOverride = "override"
+	RateSourceSeason   = "season"
+	RateSourceBase     = "base"
+)
+
+// Season prices a room type for the nights From through To inclusive.
+// WeekendPrice, when set, applies to Friday and Saturday nights instead of
+// Price. Where seasons overlap, the one starting latest wins.
+type Season struct {
+	ID           string
+	RoomType     string
+	Name         string
+	From         propertydomain.Date
+	To           propertydomain.Date
+	Price        float64
+	WeekendPrice float64
+}
+
+// RateOverride fixes a room type's price for one night, ahead of any season.
+type RateOverride struct {
+	RoomType string
+	Date     propertydomain.Date
+	Price    float64
+}
+
+// NightlyRate is the price of one night of a stay.
+type NightlyRate struct {
+	Date  propertydomain.Date
+	Price float64
+}
+
+// RateCalendar prices nights from seasons and overrides. Room types without
+// either on a night fall back to the room's BasePrice.
+type RateCalendar struct {
+	seasons   []Season
+	overrides map[string]float64
+}
+
+func NewRateCalendar(seasons []Season, overrides []RateOverride) RateCalendar {
+	c := RateCalendar{
+		seasons:   append([]Season(nil), seasons...),
+		overrides: make(map[string]float64, len(overrides)),
+	}
+	for _, o := range overrides {
+		c.overrides[rateKey(o.RoomType, o.Date)] = o.Price
+	}
+	return c
+}
+
+// Lookup returns the room type's price for the night and where it came
+// from, or RateSourceBase with a zero price when neither a season nor an
+// override covers the night.
+func (c RateCalendar) Lookup(roomType string, night propertydomain.Date) (float64, string) {
+	if price, ok := c.overrides[rateKey(roomType, night)]; ok {
+		return price, RateSourceOverride
+	}
+
+	var match *Season
+	for i, s := range c.seasons {
+		if !strings.EqualFold(s.RoomType, roomType) {
+			continue
+		}
+		if night.Before(s.From) || night.After(s.To) {
+			continue
+		}
+		if match == nil || s.From.After(match.From) {
+			match = &c.seasons[i]
+		}
+	}
+	if match == nil {
+		return 0, RateSourceBase
+	}
+	if match.WeekendPrice > 0 && isWeekendNight(night) {
+		return match.WeekendPrice, RateSourceSeason
+	}
+	return match.Price, RateSourceSeason
+}
+
+// Rate returns the price of one night in the room.
+func (c RateCalendar) Rate(room Room, night propertydomain.Date) float64 {
+	if price, source := c.Lookup(room.Type, night); source != RateSourceBase {
+		return price
+	}
+	return room.BasePrice
+}
+
+// Stay prices every night from checkIn up to checkOut in the room and
+// returns the nightly rates with their total.
+func (c RateCalendar) Stay(room Room, checkIn, checkOut propertydomain.Date) ([]NightlyRate, float64) {
+	var rates []NightlyRate
+	total := 0.0
+	for d := checkIn; d.Before(checkOut); d = d.AddDays(1) {
+		price := c.Rate(room, d)
+		rates = append(rates, NightlyRate{Date: d, Price: price})
+		total += price
+	}
+	return rates, total
+}
+
+func isWeekendNight(night propertydomain.Date) bool {
+	switch night.Time().Weekday() {
+	case time.Friday, time.Saturday:
+		return true
+	default:
+		return false
+	}
+}
+
+func rateKey(roomType string, date propertydomain.Date) string {
+	return strings.ToLower(strings.TrimSpace(roomType)) + "|" + date.String()
+}
@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// Channels a rate plan can be sold through.
+const (
+	ChannelWeb       = "web"
+	ChannelFrontDesk = "front-desk"
+)
+
+// RatePlan is one way of selling a room, such as "Flexible" or
+// "Breakfast included", with its own price, cancellation terms and
+// inclusions. RoomTypes and Channels limit where it is offered; empty means
+// everywhere.
+type RatePlan struct {
+	ID           string
+	Name         string
+	RoomTypes    []string
+	Channels     []string
+	Price        PriceRule
+	Cancellation CancellationPolicy
+	Inclusions   []string
+	Active       bool
+}
+
+// PriceRule derives a plan's nightly price from the calendar rate: Percent
+// adjusts the rate (-10 sells 10% cheaper), then PerNight and PerGuest for
+// every guest are added.
+type PriceRule struct {
+	Percent  float64
+	PerNight float64
+	PerGuest float64
+}
+
+// CancellationPolicy says what cancelling costs. Non-refundable stays are
+// paid in full at booking and keep everything when cancelled; otherwise a
+// booking is free to cancel until FreeCancellation before the standard
+// check-in time on arrival, and costs FeeNights nights after that.
+type CancellationPolicy struct {
+	NonRefundable    bool
+	FreeCancellation time.Duration
+	FeeNights        float64
+}
+
+// Offered reports whether the plan can be sold for the room type on the
+// channel.
+func (p RatePlan) Offered(roomType, channel string) bool {
+	if !p.Active {
+		return false
+	}
+	return matchesAny(p.RoomTypes, roomType) && matchesAny(p.Channels, channel)
+}
+
+// Apply prices one night under the rule for the given number of guests.
+func (r PriceRule) Apply(rate float64, guests int) float64 {
+	if guests < 1 {
+		guests = 1
+	}
+	price := rate*(1+r.Percent/100) + r.PerNight + r.PerGuest*float64(guests)
+	return math.Round(math.Max(price, 0)*100) / 100
+}
+
+// Discounted reports whether the plan sells any of the calendar rates for
+// less than the rate itself.
+func (p RatePlan) Discounted(calendar []float64, guests int) bool {
+	for _, rate := range calendar {
+		if p.Price.Apply(rate, guests) < rate {
+			return true
+		}
+	}
+	return false
+}
+
+// Stay prices calendar rates under the plan and returns them with their
+// total.
+func (p RatePlan) Stay(nightly []NightlyRate, guests int) ([]NightlyRate, float64) {
+	rates := make([]NightlyRate, 0, len(nightly))
+	total := 0.0
+	for _, n := range nightly {
+		price := p.Price.Apply(n.Price, guests)
+		rates = append(rates, NightlyRate{Date: n.Date, Price: price})
+		total += price
+	}
+	return rates, math.Round(total*100) / 100
+}
+
+func matchesAny(values []string, value string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
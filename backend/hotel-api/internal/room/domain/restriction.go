@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+
+	propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
+)
+
+// Codes reported when a stay breaks a booking rule, so the guest UI can
+// explain why the dates cannot be booked.
+const (
+	ViolationMinStay           = "min_stay"
+	ViolationMaxStay           = "max_stay"
+	ViolationClosedToArrival   = "closed_to_arrival"
+	ViolationClosedToDeparture = "closed_to_departure"
+)
+
+// StayRestriction limits stays of a room type around one date. An empty
+// RoomType applies to every type; a type-specific restriction replaces the
+// all-types one for the same date. MinStay and MaxStay are nights and are
+// checked against the restriction on the arrival date; zero means no limit.
+type StayRestriction struct {
+	RoomType          string
+	Date              propertydomain.Date
+	MinStay           int
+	MaxStay           int
+	ClosedToArrival   bool
+	ClosedToDeparture bool
+}
+
+// RestrictionError is returned when a stay breaks a stay restriction or
+// falls outside the booking horizon.
+type RestrictionError struct {
+	Code string
+	Date propertydomain.Date
+	// Limit is the number the rule allows, such as the minimum nights.
+	Limit int
+}
+
+func (e *RestrictionError) Error() string {
+	day := e.Date.String()
+	switch e.Code {
+	case ViolationMinStay:
+		return fmt.Sprintf("stays arriving on %s must be at least %d nights", day, e.Limit)
+	case ViolationMaxStay:
+		return fmt.Sprintf("stays arriving on %s can be at most %d nights", day, e.Limit)
+	case ViolationClosedToArrival:
+		return fmt.Sprintf("arrivals are closed on %s", day)
+	case ViolationClosedToDeparture:
+		return fmt.Sprintf("departures are closed on %s", day)
+	case ViolationCheckInPast:
+		return fmt.Sprintf("check-in date %s is in the past", day)
+	case ViolationMinLeadTime:
+		return fmt.Sprintf("bookings must be made at least %d days before arrival", e.Limit)
+	case ViolationMaxAdvance:
+		return fmt.Sprintf("bookings can be made at most %d days in advance", e.Limit)
+	case ViolationSameDayCutoff:
+		return fmt.Sprintf("same-day bookings for %s are closed", day)
+	}
+	return e.Code
+}
+
+// Restrictions resolves the stay restrictions that apply to a room type.
+type Restrictions struct {
+	list []StayRestriction
+}
+
+func NewRestrictions(list []StayRestriction) Restrictions {
+	return Restrictions{list: list}
+}
+
+// On returns the restriction for the room type on the date, if any.
+func (r Restrictions) On(roomType string, date propertydomain.Date) (StayRestriction, bool) {
+	var found StayRestriction
+	ok := false
+	for _, sr := range r.list {
+		if sr.Date != date {
+			continue
+		}
+		if sr.RoomType == "" {
+			if !ok {
+				found, ok = sr, true
+			}
+			continue
+		}
+		if strings.EqualFold(sr.RoomType, roomType) {
+			return sr, true
+		}
+	}
+	return found, ok
+}
+
+// Check reports the first rule the stay breaks for the room type, or nil.
+func (r Restrictions) Check(roomType string, checkIn, checkOut propertydomain.Date) *RestrictionError {
+	nights := checkIn.DaysUntil(checkOut)
+
+	if arrival, ok := r.On(roomType, checkIn); ok {
+		if arrival.ClosedToArrival {
+			return &RestrictionError{Code: ViolationClosedToArrival, Date: checkIn}
+		}
+		if arrival.MinStay > 0 && nights < arrival.MinStay {
+			return &RestrictionError{Code: ViolationMinStay, Date: checkIn, Limit: arrival.MinStay}
+		}
+		if arrival.MaxStay > 0 && nights > arrival.MaxStay {
+			return &RestrictionError{Code: ViolationMaxStay, Date: checkIn, Limit: arrival.MaxStay}
+		}
+	}
+	if departure, ok := r.On(roomType, checkOut); ok && departure.ClosedToDeparture {
+		return &RestrictionError{Code: ViolationClosedToDeparture, Date: checkOut}
+	}
+	return nil
+}
@@ -1,5 +1,7 @@
 package domain
 
+import "strings"
+
 // Room represents a room type or unit that can be booked.
 type Room struct {
 	ID        string
@@ -9,3 +11,9 @@ type Room struct {
 	BasePrice float64
 	Status    string
 }
+
+// Sellable reports whether the room can be given to guests at all.
+func (r Room) Sellable() bool {
+	status := strings.ToLower(strings.TrimSpace(r.Status))
+	return status == "" || status == "available"
+}
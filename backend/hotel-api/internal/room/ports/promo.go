@@ -0,0 +1,28 @@
+package ports
+
+import (
+	"context"
+	"errors"
+
+	"github.com/yourorg/hotel-api/internal/room/domain"
+)
+
+// Errors a PromoRepository reports when a redemption would go over the
+// code's usage limits.
+var (
+	ErrPromoLimitReached = errors.New("promo code usage limit reached")
+	ErrPromoGuestLimit   = errors.New("promo code per-guest usage limit reached")
+)
+
+// PromoRepository stores promo codes and their redemptions. RedeemPromo
+// checks the stored code's usage limits and records the redemption in one
+// step, so concurrent bookings cannot exceed them.
+type PromoRepository interface {
+	ListPromos(ctx context.Context) ([]domain.Promo, error)
+	FindPromo(ctx context.Context, code string) (*domain.Promo, error)
+	SavePromo(ctx context.Context, promo domain.Promo) error
+	DeletePromo(ctx context.Context, code string) error
+	ListPromoRedemptions(ctx context.Context, code string) ([]domain.PromoRedemption, error)
+	RedeemPromo(ctx context.Context, redemption domain.PromoRedemption) error
+	ReleasePromo(ctx context.Context, code, bookingID string) error
+}
@@ -2,14 +2,14 @@ package ports
 
 import (
 	"context"
-	"time"
 
+	propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
 	"github.com/yourorg/hotel-api/internal/room/domain"
 )
 
 type SearchParams struct {
-	CheckIn  time.Time
-	CheckOut time.Time
+	CheckIn  propertydomain.Date
+	CheckOut propertydomain.Date
 	Guests   int
 }
 
@@ -21,3 +21,41 @@ type RoomRepository interface {
 	DeleteRoom(ctx context.Context, id string) error
 	FindRoomByID(ctx context.Context, id string) (*domain.Room, error)
 }
+
+// RestrictionRepository stores stay restrictions per room type and date and
+// the property-wide booking horizon.
+type RestrictionRepository interface {
+	ListRestrictions(ctx context.Context) ([]domain.StayRestriction, error)
+	SaveRestriction(ctx context.Context, restriction domain.StayRestriction) error
+	DeleteRestriction(ctx context.Context, roomType string, date propertydomain.Date) error
+	FindBookingHorizon(ctx context.Context) (domain.BookingHorizon, error)
+	SaveBookingHorizon(ctx context.Context, horizon domain.BookingHorizon) error
+}
+
+// RateRepository stores the seasons and per-date overrides of the rate
+// calendar.
+type RateRepository interface {
+	ListSeasons(ctx context.Context) ([]domain.Season, error)
+	SaveSeason(ctx context.Context, season domain.Season) error
+	DeleteSeason(ctx context.Context, id string) error
+	ListRateOverrides(ctx context.Context) ([]domain.RateOverride, error)
+	// SaveRateOverrides stores the overrides together, replacing any for the
+	// same room type and date.
+	SaveRateOverrides(ctx context.Context, overrides []domain.RateOverride) error
+	DeleteRateOverride(ctx context.Context, roomType string, date propertydomain.Date) error
+}
+
+// RatePlanRepository stores the rate plans rooms are sold under.
+type RatePlanRepository interface {
+	ListRatePlans(ctx context.Context) ([]domain.RatePlan, error)
+	FindRatePlan(ctx context.Context, id string) (*domain.RatePlan, error)
+	SaveRatePlan(ctx context.Context, plan domain.RatePlan) error
+	DeleteRatePlan(ctx context.Context, id string) error
+}
+
+// OccupancyRepository stores the property's age bands and extra-guest
+// charges per room type.
+type OccupancyRepository interface {
+	FindOccupancyPricing(ctx context.Context) (domain.OccupancyPricing, error)
+	SaveOccupancyPricing(ctx context.Context, pricing domain.OccupancyPricing) error
+}
@@ -0,0 +1,127 @@
+package seed
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+
+	bookingdomain "github.com/yourorg/hotel-api/internal/booking/domain"
+	bookingports "github.com/yourorg/hotel-api/internal/booking/ports"
+)
+
+// Search implements bookingports.BookingRepository.
+func (s *InMemoryStore) Search(ctx context.Context, query bookingports.BookingQuery) (bookingports.BookingPage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	select {
+	case <-ctx.Done():
+		return bookingports.BookingPage{}, ctx.Err()
+	default:
+	}
+
+	text := strings.ToLower(strings.TrimSpace(query.Text))
+	var matched []bookingdomain.Booking
+	for _, b := range s.bookings {
+		if matchesQuery(b, query, text) {
+			matched = append(matched, b)
+		}
+	}
+
+	slices.SortStableFunc(matched, func(a, b bookingdomain.Booking) int {
+		return compareBookings(bookingports.KeyOf(a), bookingports.KeyOf(b), query.Sort, query.Descending)
+	})
+
+	page := bookingports.BookingPage{Total: len(matched)}
+	start := 0
+	if query.After != nil {
+		start = sort.Search(len(matched), func(i int) bool {
+			return compareBookings(bookingports.KeyOf(matched[i]), *query.After, query.Sort, query.Descending) > 0
+		})
+	}
+	end := len(matched)
+	if query.Limit > 0 && start+query.Limit < end {
+		end = start + query.Limit
+	}
+	page.Bookings = matched[start:end]
+	page.More = end < len(matched)
+	return page, nil
+}
+
+func matchesQuery(b bookingdomain.Booking, q bookingports.BookingQuery, text string) bool {
+	if len(q.Statuses) > 0 && !containsFold(q.Statuses, b.Status) {
+		return false
+	}
+	if q.RoomIDs != nil && !slices.ContainsFunc(q.RoomIDs, b.UsesRoom) {
+		return false
+	}
+	if q.UserIDs != nil && !slices.Contains(q.UserIDs, b.UserID) {
+		return false
+	}
+	if q.From != nil && b.CheckIn.Before(*q.From) {
+		return false
+	}
+	if q.To != nil && b.CheckOut.After(*q.To) {
+		return false
+	}
+	if q.ArrivalOn != nil && b.CheckIn != *q.ArrivalOn {
+		return false
+	}
+	if q.DepartureOn != nil && b.CheckOut != *q.DepartureOn {
+		return false
+	}
+	if q.Code != "" && b.ConfirmationCode != q.Code {
+		return false
+	}
+	if text != "" && !matchesText(b, text) {
+		return false
+	}
+	return true
+}
+
+func matchesText(b bookingdomain.Booking, text string) bool {
+	fields := []string{b.ID, b.ConfirmationCode, b.SpecialRequests}
+	for _, n := range b.StaffNotes {
+		fields = append(fields, n.Text)
+	}
+	for _, f := range fields {
+		if strings.Contains(strings.ToLower(f), text) {
+			return true
+		}
+	}
+	return false
+}
+
+// compareBookings orders booking keys by the given field, falling back to
+// the booking ID so that every booking has a fixed place to page from.
+func compareBookings(a, b bookingports.BookingKey, field string, desc bool) int {
+	var c int
+	switch field {
+	case bookingports.SortByCheckOut:
+		c = a.CheckOut.Compare(b.CheckOut)
+	case bookingports.SortByCreatedAt:
+		c = a.CreatedAt.Compare(b.CreatedAt)
+	case bookingports.SortByStatus:
+		c = strings.Compare(a.Status, b.Status)
+	case bookingports.SortByID:
+	default:
+		c = a.CheckIn.Compare(b.CheckIn)
+	}
+	if c == 0 {
+		c = strings.Compare(a.ID, b.ID)
+	}
+	if desc {
+		return -c
+	}
+	return c
+}
+
+func containsFold(values []string, v string) bool {
+	for _, candidate := range values {
+		if strings.EqualFold(candidate, v) {
+			return true
+		}
+	}
+	return false
+}
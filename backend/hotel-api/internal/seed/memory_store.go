@@ -3,35 +3,83 @@ package seed
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	authdomain "github.com/yourorg/hotel-api/internal/auth/domain"
 	authports "github.com/yourorg/hotel-api/internal/auth/ports"
 	bookingdomain "github.com/yourorg/hotel-api/internal/booking/domain"
 	bookingports "github.com/yourorg/hotel-api/internal/booking/ports"
+	propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
 	roomdomain "github.com/yourorg/hotel-api/internal/room/domain"
 	roomports "github.com/yourorg/hotel-api/internal/room/ports"
 )
 
 type InMemoryStore struct {
-	users    map[string]authdomain.User
-	rooms    map[string]roomdomain.Room
-	bookings map[string]bookingdomain.Booking
+	mu        sync.RWMutex
+	users     map[string]authdomain.User
+	sessions  map[string]string
+	rooms     map[string]roomdomain.Room
+	bookings  map[string]bookingdomain.Booking
+	history   map[string][]bookingdomain.HistoryEntry
+	limits    map[string]bookingdomain.OverbookingLimit
+	restrict  map[string]roomdomain.StayRestriction
+	horizon   roomdomain.BookingHorizon
+	seasons   map[string]roomdomain.Season
+	rates     map[string]roomdomain.RateOverride
+	plans     map[string]roomdomain.RatePlan
+	promos    map[string]roomdomain.Promo
+	redeemed  map[string][]roomdomain.PromoRedemption
+	occupancy roomdomain.OccupancyPricing
+	invoices  []bookingdomain.Invoice
+	sequence  map[string]int
+	audits    map[propertydomain.Date]bookingdomain.AuditReport
+	calendars map[string]string
 }
 
 var _ authports.UserRepository = (*InMemoryStore)(nil)
+var _ authports.SessionRepository = (*InMemoryStore)(nil)
 var _ roomports.RoomRepository = (*InMemoryStore)(nil)
+var _ roomports.RestrictionRepository = (*InMemoryStore)(nil)
+var _ roomports.RateRepository = (*InMemoryStore)(nil)
+var _ roomports.RatePlanRepository = (*InMemoryStore)(nil)
+var _ roomports.PromoRepository = (*InMemoryStore)(nil)
+var _ roomports.OccupancyRepository = (*InMemoryStore)(nil)
 var _ bookingports.BookingRepository = (*InMemoryStore)(nil)
+var _ bookingports.HistoryRepository = (*InMemoryStore)(nil)
+var _ bookingports.OverbookingRepository = (*InMemoryStore)(nil)
+var _ bookingports.InvoiceRepository = (*InMemoryStore)(nil)
+var _ bookingports.AuditRepository = (*InMemoryStore)(nil)
+var _ bookingports.CalendarTokenRepository = (*InMemoryStore)(nil)
 
 func NewInMemoryStore() *InMemoryStore {
 	return &InMemoryStore{
-		users:    make(map[string]authdomain.User),
-		rooms:    make(map[string]roomdomain.Room),
-		bookings: make(map[string]bookingdomain.Booking),
+		users:     make(map[string]authdomain.User),
+		sessions:  make(map[string]string),
+		rooms:     make(map[string]roomdomain.Room),
+		bookings:  make(map[string]bookingdomain.Booking),
+		history:   make(map[string][]bookingdomain.HistoryEntry),
+		limits:    make(map[string]bookingdomain.OverbookingLimit),
+		restrict:  make(map[string]roomdomain.StayRestriction),
+		horizon:   roomdomain.DefaultBookingHorizon(),
+		seasons:   make(map[string]roomdomain.Season),
+		rates:     make(map[string]roomdomain.RateOverride),
+		plans:     make(map[string]roomdomain.RatePlan),
+		promos:    make(map[string]roomdomain.Promo),
+		redeemed:  make(map[string][]roomdomain.PromoRedemption),
+		sequence:  make(map[string]int),
+		audits:    make(map[propertydomain.Date]bookingdomain.AuditReport),
+		calendars: make(map[string]string),
 	}
 }
 
 func (s *InMemoryStore) SaveUser(ctx context.Context, user authdomain.User) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
@@ -44,7 +92,40 @@ func (s *InMemoryStore) SaveUser(ctx context.Context, user authdomain.User) erro
 	return nil
 }
 
+// SaveSession implements authports.SessionRepository.
+func (s *InMemoryStore) SaveSession(ctx context.Context, token, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	if token == "" {
+		return errors.New("session token required")
+	}
+	s.sessions[token] = userID
+	return nil
+}
+
+// FindSession implements authports.SessionRepository.
+func (s *InMemoryStore) FindSession(ctx context.Context, token string) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	default:
+	}
+	return s.sessions[token], nil
+}
+
 func (s *InMemoryStore) FindByEmail(ctx context.Context, email string) (*authdomain.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	select {
 	case <-ctx.Done():
 		return nil, ctx.Err()
@@ -59,7 +140,47 @@ func (s *InMemoryStore) FindByEmail(ctx context.Context, email string) (*authdom
 	return nil, nil
 }
 
+func (s *InMemoryStore) FindUserByID(ctx context.Context, id string) (*authdomain.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	u, ok := s.users[id]
+	if !ok {
+		return nil, nil
+	}
+	return &u, nil
+}
+
+// FindUsers implements authports.UserRepository.
+func (s *InMemoryStore) FindUsers(ctx context.Context, text string) ([]authdomain.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	text = strings.ToLower(text)
+	var result []authdomain.User
+	for _, u := range s.users {
+		if strings.Contains(strings.ToLower(u.Email), text) || strings.Contains(strings.ToLower(u.Name), text) {
+			result = append(result, u)
+		}
+	}
+	return result, nil
+}
+
 func (s *InMemoryStore) SaveRoom(ctx context.Context, room roomdomain.Room) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
@@ -74,6 +195,9 @@ func (s *InMemoryStore) SaveRoom(ctx context.Context, room roomdomain.Room) erro
 
 // UpdateRoomStatus implements roomports.RoomRepository.
 func (s *InMemoryStore) UpdateRoomStatus(ctx context.Context, id string, status string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
@@ -90,6 +214,9 @@ func (s *InMemoryStore) UpdateRoomStatus(ctx context.Context, id string, status
 
 // DeleteRoom implements roomports.RoomRepository.
 func (s *InMemoryStore) DeleteRoom(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
@@ -104,6 +231,9 @@ func (s *InMemoryStore) DeleteRoom(ctx context.Context, id string) error {
 
 // ListRooms implements roomports.RoomRepository.
 func (s *InMemoryStore) ListRooms(ctx context.Context) ([]roomdomain.Room, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	select {
 	case <-ctx.Done():
 		return nil, ctx.Err()
@@ -119,6 +249,9 @@ func (s *InMemoryStore) ListRooms(ctx context.Context) ([]roomdomain.Room, error
 
 // FindRoomByID implements roomports.RoomRepository.
 func (s *InMemoryStore) FindRoomByID(ctx context.Context, id string) (*roomdomain.Room, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	select {
 	case <-ctx.Done():
 		return nil, ctx.Err()
@@ -133,6 +266,9 @@ func (s *InMemoryStore) FindRoomByID(ctx context.Context, id string) (*roomdomai
 }
 
 func (s *InMemoryStore) SearchAvailable(ctx context.Context, params roomports.SearchParams) ([]roomdomain.Room, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	select {
 	case <-ctx.Done():
 		return nil, ctx.Err()
@@ -153,6 +289,9 @@ func (s *InMemoryStore) SearchAvailable(ctx context.Context, params roomports.Se
 }
 
 func (s *InMemoryStore) SaveBooking(ctx context.Context, booking bookingdomain.Booking) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
@@ -174,6 +313,9 @@ func (s *InMemoryStore) Create(ctx context.Context, booking bookingdomain.Bookin
 }
 
 func (s *InMemoryStore) Update(ctx context.Context, booking bookingdomain.Booking) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
@@ -186,7 +328,28 @@ func (s *InMemoryStore) Update(ctx context.Context, booking bookingdomain.Bookin
 	return nil
 }
 
+// UpdateIfStatus implements bookingports.BookingRepository.
+func (s *InMemoryStore) UpdateIfStatus(ctx context.Context, booking bookingdomain.Booking, status string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	current, ok := s.bookings[booking.ID]
+	if !ok || current.Status != status {
+		return bookingports.ErrBookingChanged
+	}
+	s.bookings[booking.ID] = booking
+	return nil
+}
+
 func (s *InMemoryStore) FindByUser(ctx context.Context, userID string) ([]bookingdomain.Booking, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	select {
 	case <-ctx.Done():
 		return nil, ctx.Err()
@@ -203,6 +366,9 @@ func (s *InMemoryStore) FindByUser(ctx context.Context, userID string) ([]bookin
 }
 
 func (s *InMemoryStore) List(ctx context.Context) ([]bookingdomain.Booking, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	select {
 	case <-ctx.Done():
 		return nil, ctx.Err()
@@ -217,6 +383,9 @@ func (s *InMemoryStore) List(ctx context.Context) ([]bookingdomain.Booking, erro
 }
 
 func (s *InMemoryStore) FindByID(ctx context.Context, id string) (*bookingdomain.Booking, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	select {
 	case <-ctx.Done():
 		return nil, ctx.Err()
@@ -229,3 +398,665 @@ func (s *InMemoryStore) FindByID(ctx context.Context, id string) (*bookingdomain
 	}
 	return nil, nil
 }
+
+func (s *InMemoryStore) FindByConfirmationCode(ctx context.Context, code string) (*bookingdomain.Booking, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	for _, b := range s.bookings {
+		if b.ConfirmationCode == code {
+			booking := b
+			return &booking, nil
+		}
+	}
+	return nil, nil
+}
+
+// AppendHistory implements bookingports.HistoryRepository.
+func (s *InMemoryStore) AppendHistory(ctx context.Context, entry bookingdomain.HistoryEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	if entry.BookingID == "" {
+		return errors.New("history booking id required")
+	}
+	s.history[entry.BookingID] = append(s.history[entry.BookingID], entry)
+	return nil
+}
+
+// ListHistory implements bookingports.HistoryRepository.
+func (s *InMemoryStore) ListHistory(ctx context.Context, bookingID string) ([]bookingdomain.HistoryEntry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	entries := s.history[bookingID]
+	return append([]bookingdomain.HistoryEntry(nil), entries...), nil
+}
+
+// ListOverbookingLimits implements bookingports.OverbookingRepository.
+func (s *InMemoryStore) ListOverbookingLimits(ctx context.Context) ([]bookingdomain.OverbookingLimit, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	var limits []bookingdomain.OverbookingLimit
+	for _, l := range s.limits {
+		limits = append(limits, l)
+	}
+	return limits, nil
+}
+
+// SaveOverbookingLimit implements bookingports.OverbookingRepository.
+func (s *InMemoryStore) SaveOverbookingLimit(ctx context.Context, limit bookingdomain.OverbookingLimit) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	s.limits[typeDateKey(limit.RoomType, limit.Date)] = limit
+	return nil
+}
+
+// DeleteOverbookingLimit implements bookingports.OverbookingRepository.
+func (s *InMemoryStore) DeleteOverbookingLimit(ctx context.Context, roomType string, date propertydomain.Date) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	key := typeDateKey(roomType, date)
+	if _, ok := s.limits[key]; !ok {
+		return errors.New("overbooking limit not found")
+	}
+	delete(s.limits, key)
+	return nil
+}
+
+func typeDateKey(roomType string, date propertydomain.Date) string {
+	day := ""
+	if !date.IsZero() {
+		day = date.String()
+	}
+	return strings.ToLower(roomType) + "|" + day
+}
+
+// ListRestrictions implements roomports.RestrictionRepository.
+func (s *InMemoryStore) ListRestrictions(ctx context.Context) ([]roomdomain.StayRestriction, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	var restrictions []roomdomain.StayRestriction
+	for _, r := range s.restrict {
+		restrictions = append(restrictions, r)
+	}
+	return restrictions, nil
+}
+
+// SaveRestriction implements roomports.RestrictionRepository.
+func (s *InMemoryStore) SaveRestriction(ctx context.Context, restriction roomdomain.StayRestriction) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	s.restrict[typeDateKey(restriction.RoomType, restriction.Date)] = restriction
+	return nil
+}
+
+// DeleteRestriction implements roomports.RestrictionRepository.
+func (s *InMemoryStore) DeleteRestriction(ctx context.Context, roomType string, date propertydomain.Date) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	key := typeDateKey(roomType, date)
+	if _, ok := s.restrict[key]; !ok {
+		return errors.New("restriction not found")
+	}
+	delete(s.restrict, key)
+	return nil
+}
+
+// FindBookingHorizon implements roomports.RestrictionRepository.
+func (s *InMemoryStore) FindBookingHorizon(ctx context.Context) (roomdomain.BookingHorizon, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	select {
+	case <-ctx.Done():
+		return roomdomain.BookingHorizon{}, ctx.Err()
+	default:
+	}
+	return s.horizon, nil
+}
+
+// SaveBookingHorizon implements roomports.RestrictionRepository.
+func (s *InMemoryStore) SaveBookingHorizon(ctx context.Context, horizon roomdomain.BookingHorizon) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	s.horizon = horizon
+	return nil
+}
+
+// ListSeasons implements roomports.RateRepository.
+func (s *InMemoryStore) ListSeasons(ctx context.Context) ([]roomdomain.Season, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	var seasons []roomdomain.Season
+	for _, season := range s.seasons {
+		seasons = append(seasons, season)
+	}
+	return seasons, nil
+}
+
+// SaveSeason implements roomports.RateRepository.
+func (s *InMemoryStore) SaveSeason(ctx context.Context, season roomdomain.Season) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	s.seasons[season.ID] = season
+	return nil
+}
+
+// DeleteSeason implements roomports.RateRepository.
+func (s *InMemoryStore) DeleteSeason(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	if _, ok := s.seasons[id]; !ok {
+		return errors.New("season not found")
+	}
+	delete(s.seasons, id)
+	return nil
+}
+
+// ListRateOverrides implements roomports.RateRepository.
+func (s *InMemoryStore) ListRateOverrides(ctx context.Context) ([]roomdomain.RateOverride, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	var overrides []roomdomain.RateOverride
+	for _, o := range s.rates {
+		overrides = append(overrides, o)
+	}
+	return overrides, nil
+}
+
+// SaveRateOverrides implements roomports.RateRepository.
+func (s *InMemoryStore) SaveRateOverrides(ctx context.Context, overrides []roomdomain.RateOverride) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	for _, o := range overrides {
+		s.rates[typeDateKey(o.RoomType, o.Date)] = o
+	}
+	return nil
+}
+
+// DeleteRateOverride implements roomports.RateRepository.
+func (s *InMemoryStore) DeleteRateOverride(ctx context.Context, roomType string, date propertydomain.Date) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	key := typeDateKey(roomType, date)
+	if _, ok := s.rates[key]; !ok {
+		return errors.New("rate override not found")
+	}
+	delete(s.rates, key)
+	return nil
+}
+
+// ListRatePlans implements roomports.RatePlanRepository.
+func (s *InMemoryStore) ListRatePlans(ctx context.Context) ([]roomdomain.RatePlan, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	var plans []roomdomain.RatePlan
+	for _, plan := range s.plans {
+		plans = append(plans, plan)
+	}
+	return plans, nil
+}
+
+// FindRatePlan implements roomports.RatePlanRepository.
+func (s *InMemoryStore) FindRatePlan(ctx context.Context, id string) (*roomdomain.RatePlan, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	if plan, ok := s.plans[id]; ok {
+		return &plan, nil
+	}
+	return nil, nil
+}
+
+// SaveRatePlan implements roomports.RatePlanRepository.
+func (s *InMemoryStore) SaveRatePlan(ctx context.Context, plan roomdomain.RatePlan) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	s.plans[plan.ID] = plan
+	return nil
+}
+
+// DeleteRatePlan implements roomports.RatePlanRepository.
+func (s *InMemoryStore) DeleteRatePlan(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	if _, ok := s.plans[id]; !ok {
+		return errors.New("rate plan not found")
+	}
+	delete(s.plans, id)
+	return nil
+}
+
+// ListPromos implements roomports.PromoRepository.
+func (s *InMemoryStore) ListPromos(ctx context.Context) ([]roomdomain.Promo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	var promos []roomdomain.Promo
+	for _, promo := range s.promos {
+		promos = append(promos, promo)
+	}
+	return promos, nil
+}
+
+// FindPromo implements roomports.PromoRepository.
+func (s *InMemoryStore) FindPromo(ctx context.Context, code string) (*roomdomain.Promo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	if promo, ok := s.promos[code]; ok {
+		return &promo, nil
+	}
+	return nil, nil
+}
+
+// SavePromo implements roomports.PromoRepository.
+func (s *InMemoryStore) SavePromo(ctx context.Context, promo roomdomain.Promo) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	s.promos[promo.Code] = promo
+	return nil
+}
+
+// DeletePromo implements roomports.PromoRepository. Redemptions are kept.
+func (s *InMemoryStore) DeletePromo(ctx context.Context, code string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	if _, ok := s.promos[code]; !ok {
+		return errors.New("promo not found")
+	}
+	delete(s.promos, code)
+	return nil
+}
+
+// ListPromoRedemptions implements roomports.PromoRepository.
+func (s *InMemoryStore) ListPromoRedemptions(ctx context.Context, code string) ([]roomdomain.PromoRedemption, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	return append([]roomdomain.PromoRedemption(nil), s.redeemed[code]...), nil
+}
+
+// RedeemPromo implements roomports.PromoRepository.
+func (s *InMemoryStore) RedeemPromo(ctx context.Context, redemption roomdomain.PromoRedemption) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	promo, ok := s.promos[redemption.Code]
+	if !ok {
+		return errors.New("promo not found")
+	}
+	used := s.redeemed[redemption.Code]
+	if promo.MaxUses > 0 && len(used) >= promo.MaxUses {
+		return roomports.ErrPromoLimitReached
+	}
+	if promo.MaxUsesPerGuest > 0 {
+		byGuest := 0
+		for _, r := range used {
+			if r.UserID == redemption.UserID {
+				byGuest++
+			}
+		}
+		if byGuest >= promo.MaxUsesPerGuest {
+			return roomports.ErrPromoGuestLimit
+		}
+	}
+	s.redeemed[redemption.Code] = append(used, redemption)
+	return nil
+}
+
+// ReleasePromo implements roomports.PromoRepository.
+func (s *InMemoryStore) ReleasePromo(ctx context.Context, code, bookingID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	used := s.redeemed[code]
+	for i, r := range used {
+		if r.BookingID == bookingID {
+			s.redeemed[code] = append(used[:i:i], used[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+// FindOccupancyPricing implements roomports.OccupancyRepository.
+func (s *InMemoryStore) FindOccupancyPricing(ctx context.Context) (roomdomain.OccupancyPricing, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	select {
+	case <-ctx.Done():
+		return roomdomain.OccupancyPricing{}, ctx.Err()
+	default:
+	}
+	return s.occupancy, nil
+}
+
+// SaveOccupancyPricing implements roomports.OccupancyRepository.
+func (s *InMemoryStore) SaveOccupancyPricing(ctx context.Context, pricing roomdomain.OccupancyPricing) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	s.occupancy = pricing
+	return nil
+}
+
+// IssueInvoice implements bookingports.InvoiceRepository.
+func (s *InMemoryStore) IssueInvoice(ctx context.Context, invoice bookingdomain.Invoice) (bookingdomain.Invoice, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return bookingdomain.Invoice{}, ctx.Err()
+	default:
+	}
+
+	var docs []bookingdomain.Invoice
+	for _, inv := range s.invoices {
+		if inv.BookingID == invoice.BookingID {
+			docs = append(docs, inv)
+		}
+	}
+	switch invoice.Kind {
+	case bookingdomain.InvoiceKindInvoice:
+		if current, ok := bookingdomain.CurrentInvoice(docs); ok {
+			return current, nil
+		}
+	case bookingdomain.InvoiceKindCreditNote:
+		for _, d := range docs {
+			if d.Kind == bookingdomain.InvoiceKindCreditNote && d.Corrects == invoice.Corrects {
+				return bookingdomain.Invoice{}, bookingports.ErrInvoiceCredited
+			}
+		}
+	}
+
+	s.sequence[invoice.PropertyID]++
+	seq := s.sequence[invoice.PropertyID]
+	prefix := invoice.Property.InvoicePrefix
+	if prefix == "" {
+		prefix = "INV"
+	}
+	invoice.Sequence = seq
+	invoice.Number = fmt.Sprintf("%s-%06d", prefix, seq)
+	invoice.ID = fmt.Sprintf("invoice-%s-%d", invoice.PropertyID, seq)
+	s.invoices = append(s.invoices, invoice)
+	return invoice, nil
+}
+
+// ListInvoices implements bookingports.InvoiceRepository.
+func (s *InMemoryStore) ListInvoices(ctx context.Context, bookingID string) ([]bookingdomain.Invoice, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	var invoices []bookingdomain.Invoice
+	for _, inv := range s.invoices {
+		if inv.BookingID == bookingID {
+			invoices = append(invoices, inv)
+		}
+	}
+	return invoices, nil
+}
+
+// SaveCalendarToken implements bookingports.CalendarTokenRepository.
+func (s *InMemoryStore) SaveCalendarToken(ctx context.Context, userID, token string, replace bool) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	default:
+	}
+	if existing, ok := s.calendars[userID]; ok && !replace {
+		return existing, nil
+	}
+	s.calendars[userID] = token
+	return token, nil
+}
+
+// FindCalendarUser implements bookingports.CalendarTokenRepository.
+func (s *InMemoryStore) FindCalendarUser(ctx context.Context, token string) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	default:
+	}
+	for userID, t := range s.calendars {
+		if t == token {
+			return userID, nil
+		}
+	}
+	return "", nil
+}
+
+// SaveAuditReport implements bookingports.AuditRepository.
+func (s *InMemoryStore) SaveAuditReport(ctx context.Context, report bookingdomain.AuditReport) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	s.audits[report.Date] = report
+	return nil
+}
+
+// FindAuditReport implements bookingports.AuditRepository.
+func (s *InMemoryStore) FindAuditReport(ctx context.Context, date propertydomain.Date) (*bookingdomain.AuditReport, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	report, ok := s.audits[date]
+	if !ok {
+		return nil, nil
+	}
+	return &report, nil
+}
+
+// ListAuditReports implements bookingports.AuditRepository.
+func (s *InMemoryStore) ListAuditReports(ctx context.Context) ([]bookingdomain.AuditReport, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	reports := make([]bookingdomain.AuditReport, 0, len(s.audits))
+	for _, r := range s.audits {
+		reports = append(reports, r)
+	}
+	sort.Slice(reports, func(i, j int) bool { return reports[i].Date.Before(reports[j].Date) })
+	return reports, nil
+}
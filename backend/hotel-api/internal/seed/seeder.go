@@ -8,6 +8,9 @@ import (
 	"github.com/yourorg/hotel-api/internal/auth/app"
 	authdomain "github.com/yourorg/hotel-api/internal/auth/domain"
 	bookingdomain "github.com/yourorg/hotel-api/internal/booking/domain"
+	propertyapp "github.com/yourorg/hotel-api/internal/property/app"
+	propertydomain "github.com/yourorg/hotel-api/internal/property/domain"
+	propertyports "github.com/yourorg/hotel-api/internal/property/ports"
 	roomdomain "github.com/yourorg/hotel-api/internal/room/domain"
 )
 
@@ -23,34 +26,47 @@ type bookingWriter interface {
 	SaveBooking(ctx context.Context, booking bookingdomain.Booking) error
 }
 
+type ratePlanWriter interface {
+	SaveRatePlan(ctx context.Context, plan roomdomain.RatePlan) error
+}
+
+type occupancyWriter interface {
+	SaveOccupancyPricing(ctx context.Context, pricing roomdomain.OccupancyPricing) error
+}
+
 type Seeder struct {
-	users    userWriter
-	rooms    roomWriter
-	bookings bookingWriter
-	nowFn    func() time.Time
+	users     userWriter
+	rooms     roomWriter
+	bookings  bookingWriter
+	plans     ratePlanWriter
+	occupancy occupancyWriter
+	clock     propertyports.Clock
 }
 
-func NewSeeder(users userWriter, rooms roomWriter, bookings bookingWriter) *Seeder {
+func NewSeeder(users userWriter, rooms roomWriter, bookings bookingWriter, plans ratePlanWriter, occupancy occupancyWriter, clock propertyports.Clock) *Seeder {
 	return &Seeder{
-		users:    users,
-		rooms:    rooms,
-		bookings: bookings,
-		nowFn:    time.Now,
+		users:     users,
+		rooms:     rooms,
+		bookings:  bookings,
+		plans:     plans,
+		occupancy: occupancy,
+		clock:     clock,
 	}
 }
 
 func Run(ctx context.Context) error {
 	mem := NewInMemoryStore()
-	seeder := NewSeeder(mem, mem, mem)
+	seeder := NewSeeder(mem, mem, mem, mem, mem, propertyapp.NewBusinessDateService(time.UTC, false))
 	return seeder.Seed(ctx)
 }
 
 func (s *Seeder) Seed(ctx context.Context) error {
-	now := s.nowFn()
+	now := s.clock.Now()
 
 	admin := authdomain.User{
 		ID:           "user-admin-1",
 		Email:        "admin@stayflex.test",
+		Name:         "Front Desk Admin",
 		PasswordHash: app.HashForSeed("admin123"),
 		Role:         "admin",
 	}
@@ -59,12 +75,14 @@ func (s *Seeder) Seed(ctx context.Context) error {
 		{
 			ID:           "user-guest-1",
 			Email:        "guest1@stayflex.test",
+			Name:         "Alex Morgan",
 			PasswordHash: app.HashForSeed("password123"),
 			Role:         "guest",
 		},
 		{
 			ID:           "user-guest-2",
 			Email:        "guest2@stayflex.test",
+			Name:         "Sam Rivera",
 			PasswordHash: app.HashForSeed("password456"),
 			Role:         "guest",
 		},
@@ -77,42 +95,74 @@ func (s *Seeder) Seed(ctx context.Context) error {
 		{ID: "room-301", Name: "Suite 301", Type: "Suite", Capacity: 4, BasePrice: 250, Status: "available"},
 	}
 
+	flexible := roomdomain.CancellationPolicy{FreeCancellation: 48 * time.Hour, FeeNights: 1}
+	plans := []roomdomain.RatePlan{
+		{ID: "plan-flexible", Name: "Flexible", Cancellation: flexible, Active: true},
+		{
+			ID:           "plan-non-refundable",
+			Name:         "Non-refundable",
+			Price:        roomdomain.PriceRule{Percent: -10},
+			Cancellation: roomdomain.CancellationPolicy{NonRefundable: true},
+			Active:       true,
+		},
+		{
+			ID:           "plan-breakfast",
+			Name:         "Breakfast included",
+			Price:        roomdomain.PriceRule{PerGuest: 15},
+			Cancellation: flexible,
+			Inclusions:   []string{"breakfast"},
+			Active:       true,
+		},
+	}
+
 	bookings := []bookingdomain.Booking{
 		{
-			ID:        "booking-5",
-			UserID:    "user-guest-1",
-			RoomID:    "room-101",
-			CheckIn:   time.Date(2025, 12, 20, 15, 0, 0, 0, time.UTC),
-			CheckOut:  time.Date(2025, 12, 22, 11, 0, 0, 0, time.UTC),
-			Status:    "confirmed",
-			CreatedAt: now,
+			ID:               "booking-5",
+			ConfirmationCode: "SFX-7K2Q9",
+			UserID:           "user-guest-1",
+			RoomID:           "room-101",
+			RoomType:         "Standard",
+			Guests:           2,
+			CheckIn:          propertydomain.Date{Year: 2025, Month: time.December, Day: 20},
+			CheckOut:         propertydomain.Date{Year: 2025, Month: time.December, Day: 22},
+			Status:           "confirmed",
+			CreatedAt:        now,
 		},
 		{
-			ID:        "booking-7",
-			UserID:    "user-guest-1",
-			RoomID:    "room-102",
-			CheckIn:   time.Date(2025, 12, 1, 15, 0, 0, 0, time.UTC),
-			CheckOut:  time.Date(2025, 12, 5, 11, 0, 0, 0, time.UTC),
-			Status:    "confirmed",
-			CreatedAt: now,
+			ID:               "booking-7",
+			ConfirmationCode: "SFX-M4R8T",
+			UserID:           "user-guest-1",
+			RoomID:           "room-102",
+			RoomType:         "Standard",
+			Guests:           2,
+			CheckIn:          propertydomain.Date{Year: 2025, Month: time.December, Day: 1},
+			CheckOut:         propertydomain.Date{Year: 2025, Month: time.December, Day: 5},
+			Status:           "confirmed",
+			CreatedAt:        now,
 		},
 		{
-			ID:        "booking-6",
-			UserID:    "user-guest-1",
-			RoomID:    "room-201",
-			CheckIn:   time.Date(2025, 12, 12, 15, 0, 0, 0, time.UTC),
-			CheckOut:  time.Date(2025, 12, 15, 11, 0, 0, 0, time.UTC),
-			Status:    "confirmed",
-			CreatedAt: now,
+			ID:               "booking-6",
+			ConfirmationCode: "SFX-H3W6P",
+			UserID:           "user-guest-1",
+			RoomID:           "room-201",
+			RoomType:         "Deluxe",
+			Guests:           2,
+			CheckIn:          propertydomain.Date{Year: 2025, Month: time.December, Day: 12},
+			CheckOut:         propertydomain.Date{Year: 2025, Month: time.December, Day: 15},
+			Status:           "confirmed",
+			CreatedAt:        now,
 		},
 		{
-			ID:        "booking-3",
-			UserID:    "user-guest-1",
-			RoomID:    "room-301",
-			CheckIn:   time.Date(2024, 11, 1, 15, 0, 0, 0, time.UTC),
-			CheckOut:  time.Date(2024, 11, 3, 11, 0, 0, 0, time.UTC),
-			Status:    "past",
-			CreatedAt: now.Add(-time.Hour * 24 * 30),
+			ID:               "booking-3",
+			ConfirmationCode: "SFX-C9V2D",
+			UserID:           "user-guest-1",
+			RoomID:           "room-301",
+			RoomType:         "Suite",
+			Guests:           2,
+			CheckIn:          propertydomain.Date{Year: 2024, Month: time.November, Day: 1},
+			CheckOut:         propertydomain.Date{Year: 2024, Month: time.November, Day: 3},
+			Status:           "past",
+			CreatedAt:        now.Add(-time.Hour * 24 * 30),
 		},
 	}
 
@@ -126,18 +176,40 @@ func (s *Seeder) Seed(ctx context.Context) error {
 		}
 	}
 
+	noInfantCharge := func(child float64) map[string]float64 {
+		return map[string]float64{"infant": 0, "child": child}
+	}
+	occupancy := roomdomain.OccupancyPricing{
+		Bands: []roomdomain.AgeBand{{Name: "infant", MaxAge: 2}, {Name: "child", MaxAge: 12}},
+		Rules: []roomdomain.OccupancyRule{
+			{RoomType: "Standard", BaseOccupancy: 2, ExtraAdult: 30, ExtraChild: noInfantCharge(15)},
+			{RoomType: "Deluxe", BaseOccupancy: 2, ExtraAdult: 40, ExtraChild: noInfantCharge(20)},
+			{RoomType: "Suite", BaseOccupancy: 2, ExtraAdult: 50, ExtraChild: noInfantCharge(25)},
+		},
+	}
+
 	for _, room := range rooms {
 		if err := s.rooms.SaveRoom(ctx, room); err != nil {
 			return err
 		}
 	}
 
+	if err := s.occupancy.SaveOccupancyPricing(ctx, occupancy); err != nil {
+		return err
+	}
+
+	for _, plan := range plans {
+		if err := s.plans.SaveRatePlan(ctx, plan); err != nil {
+			return err
+		}
+	}
+
 	for _, booking := range bookings {
 		if err := s.bookings.SaveBooking(ctx, booking); err != nil {
 			return err
 		}
 	}
 
-	log.Printf("seeded users=%d rooms=%d bookings=%d", 1+len(guests), len(rooms), len(bookings))
+	log.Printf("seeded users=%d rooms=%d plans=%d bookings=%d", 1+len(guests), len(rooms), len(plans), len(bookings))
 	return nil
 }
@@ -3,6 +3,12 @@
 COMPOSE=${COMPOSE:-docker compose}
 BASE_URL=${BASE_URL:-http://localhost:3000}
 
+# The acceptance scenarios pin the hotel clock and book long stays that
+# take a deposit, so the API runs in test mode with a deposit rule.
+export BUSINESS_DATE_TEST_MODE=true
+export DEPOSIT_RULES='[{"minNights":5,"percent":0.3,"dueDays":3}]'
+export DEPOSIT_AUTO_CANCEL=true
+
 echo "🧹 Cleaning up existing services..."
 $COMPOSE down
 
@@ -74,6 +74,7 @@ test.describe('Admin booking overview', () => {
     }
     
     await bookingOverviewPage.markCheckedOut(bookingId);
+    await bookingOverviewPage.settleBalanceIfOwing(bookingId);
     
     // Verify status updated
     const currentStatus = await bookingOverviewPage.getStatusByBookingId(bookingId);
@@ -70,6 +70,19 @@ export default class BookingOverviewPage {
     await button.click();
   }
 
+  // settleBalanceIfOwing takes payment for the folio balance when check-out
+  // was refused because money is still owing.
+  @step("Settle outstanding balance for booking: {bookingId}")
+  async settleBalanceIfOwing(bookingId: string): Promise<void> {
+    const item = this.page.locator(`.booking-item[data-id="${bookingId}"]`);
+    const settleButton = item.locator('.settle-button');
+    const checkedOut = item.locator('.status', { hasText: 'Checked-out' });
+    await settleButton.or(checkedOut).first().waitFor({ state: 'visible' });
+    if (await settleButton.isVisible()) {
+      await settleButton.click();
+    }
+  }
+
   @step("Get booking list from overview")
   async getBookingList(): Promise<Locator[]> {
     return await this.bookingList.all();
@@ -28,5 +28,6 @@ Feature: Admin booking overview
     And I am on the admin bookings overview page
     When I select that booking on "2025-12-15" or later
     And I mark it as "Checked‑out"
+    And I settle any outstanding balance
     Then the booking status should be updated to "Checked‑out"
     And the UI should reflect the new status
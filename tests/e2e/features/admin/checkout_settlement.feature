@@ -0,0 +1,31 @@
+# language: en
+@admin @booking_overview @checkout_settlement
+Feature: Check-out settlement
+
+  As an admin user
+  I want check-out to stop while the guest still owes money
+  So that every stay leaves with a settled folio
+
+  Background:
+    Given the hotel clock reads "2030-05-03T10:00"
+    And a guest is checked in to a "Standard" room from "2030-05-03" to "2030-05-05"
+    And a "Minibar" charge of 30 is on the folio
+
+  @admin @booking_overview @checkout_settlement @settle
+  Scenario: Take the outstanding balance and check out
+    Given I am on the admin bookings overview page
+    When I filter bookings from "2030-05-03" to "2030-05-05"
+    And I check out the in-house booking
+    Then I should be asked to take a payment of "30.00"
+    When I take the payment and check out
+    Then the in-house booking should show as "Checked-out"
+    And the folio should be settled
+
+  @admin @booking_overview @checkout_settlement @override
+  Scenario: Check out with a balance owing when a reason is given
+    Given I am on the admin bookings overview page
+    When I filter bookings from "2030-05-03" to "2030-05-05"
+    And I check out the in-house booking
+    And I override the check-out with the reason "Company pays on invoice"
+    Then the in-house booking should show as "Checked-out"
+    And the folio should still owe 30
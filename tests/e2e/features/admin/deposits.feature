@@ -0,0 +1,32 @@
+# language: en
+@admin @deposit
+Feature: Booking deposits
+
+  As an admin user
+  I want long stays to pay a deposit by its due date
+  So that unpaid bookings do not hold rooms
+
+  @admin @deposit @schedule
+  Scenario: Long stay is given a deposit to pay
+    Given the hotel clock reads "2030-06-01T10:00"
+    When a guest books a "Standard" room from "2030-06-20" to "2030-06-26"
+    Then the booking should have a deposit of 180 due on "2030-06-04"
+
+  @admin @deposit @overdue
+  Scenario: Booking whose deposit is overdue is cancelled
+    Given the hotel clock reads "2030-06-01T10:00"
+    And a guest books a "Standard" room from "2030-06-21" to "2030-06-27"
+    When the hotel clock reads "2030-06-05T10:00"
+    And the deposit job runs
+    Then the booking should be "cancelled" with its deposit overdue
+    And the card authorization should be released
+
+  @admin @deposit @paid
+  Scenario: Booking whose deposit is paid is kept
+    Given the hotel clock reads "2030-06-01T10:00"
+    And a guest books a "Deluxe" room from "2030-06-20" to "2030-06-25"
+    And the guest pays the deposit
+    When the hotel clock reads "2030-06-05T10:00"
+    And the deposit job runs
+    Then the deposit job should not have processed the booking
+    And the booking should be "confirmed" with nothing left to pay on the deposit
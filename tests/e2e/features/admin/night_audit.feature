@@ -0,0 +1,29 @@
+# language: en
+@admin @night_audit
+Feature: Night audit
+
+  As an admin user
+  I want the night audit to close each business date
+  So that room nights are charged and the day's figures are final
+
+  @admin @night_audit @close_day
+  Scenario: Close the business date after midnight
+    Given the hotel clock reads "2030-07-10T10:00"
+    And a guest is in house in a "Standard" room from "2030-07-10" to "2030-07-12"
+    And a guest has an arrival in a "Deluxe" room on "2030-07-10" who never checks in
+    And the hotel clock reads "2030-07-11T02:00" on business date "2030-07-10"
+    When the night audit runs
+    Then the audit report for "2030-07-10" should show 1 room night posted
+    And the in-house folio should be charged 100 for the night of "2030-07-10"
+    And the arrival should be reported as a no-show
+    And the business date should be "2030-07-11"
+
+  @admin @night_audit @rerun
+  Scenario: Running the audit again does not charge the night twice
+    Given the hotel clock reads "2030-07-20T10:00"
+    And a guest is in house in a "Standard" room from "2030-07-20" to "2030-07-22"
+    And the hotel clock reads "2030-07-21T02:00" on business date "2030-07-20"
+    And the night audit runs
+    When the hotel clock reads "2030-07-21T02:30" on business date "2030-07-20"
+    And the night audit runs
+    Then the in-house folio should have 1 room charge
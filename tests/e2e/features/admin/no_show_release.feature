@@ -0,0 +1,27 @@
+# language: en
+@admin @no_show
+Feature: No-show release
+
+  As an admin user
+  I want arrivals that never turn up to be released after the no-show cutoff
+  So that their room can be sold again for the rest of the stay
+
+  @admin @no_show @release
+  Scenario: Booking not checked in by the cutoff becomes a no-show and frees the room
+    Given the hotel clock reads "2030-03-04T10:00"
+    And a guest has booked a "Suite" from "2030-03-05" to "2030-03-08"
+    And no "Suite" can be booked from "2030-03-06" to "2030-03-08"
+    When the hotel clock reads "2030-03-06T03:00"
+    And the no-show job runs
+    Then the booking should be marked as a no-show
+    And its card authorization should be voided
+    And a "Suite" can be booked from "2030-03-06" to "2030-03-08"
+
+  @admin @no_show @before_cutoff
+  Scenario: Booking is left alone before the no-show cutoff
+    Given the hotel clock reads "2030-03-11T10:00"
+    And a guest has booked a "Suite" from "2030-03-12" to "2030-03-14"
+    When the hotel clock reads "2030-03-12T20:00"
+    And the no-show job runs
+    Then the no-show job should not have processed the booking
+    And the booking should still be "confirmed"
@@ -0,0 +1,27 @@
+# language: en
+@guest @booking @cancellation_fee
+Feature: Cancellation fees
+
+  As a guest user
+  I want cancelling in good time to cost nothing
+  So that I only pay when I cancel close to arrival
+
+  @guest @booking @cancellation_fee @free
+  Scenario: Cancel more than two days before arrival without a fee
+    Given the hotel clock reads "2030-04-01T10:00"
+    And I hold a "Deluxe" booking from "2030-04-20" to "2030-04-22"
+    And I am on the "My Bookings" page
+    When I cancel that booking
+    Then I should see a confirmation message "Booking cancelled"
+    And the booking should be cancelled without a fee
+    And my card authorization should be voided
+
+  @guest @booking @cancellation_fee @late
+  Scenario: Cancel on the day of arrival and pay one night
+    Given the hotel clock reads "2030-04-05T10:00"
+    And I hold a "Deluxe" booking from "2030-04-05" to "2030-04-07"
+    And I am on the "My Bookings" page
+    When I cancel that booking
+    Then I should see a confirmation message "Booking cancelled"
+    And the booking should be cancelled with a cancellation fee of 180
+    And 180 should be captured from my card
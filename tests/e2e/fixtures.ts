@@ -10,6 +10,7 @@ import AdminLoginPage from './pages/admin/AdminLoginPage.js';
 import AdminDashboardPage from './pages/admin/AdminDashboardPage.js';
 import BookingOverviewPage from './pages/admin/BookingOverviewPage.js';
 import RoomManagementPage from './pages/admin/RoomManagementPage.js';
+import HotelApi from './pages/api/HotelApi.js';
 
 // Define fixture types
 type Fixtures = {
@@ -22,6 +23,7 @@ type Fixtures = {
   adminDashboardPage: AdminDashboardPage;
   bookingOverviewPage: BookingOverviewPage;
   roomManagementPage: RoomManagementPage;
+  hotelApi: HotelApi;
 };
 
 // Extend base test with page object fixtures
@@ -61,6 +63,18 @@ export const test = base.extend<Fixtures>({
   roomManagementPage: async ({ page }, use) => {
     await use(new RoomManagementPage(page));
   },
+
+  // hotelApi talks to the API at API_URL and hands a pinned clock back
+  // at the current time once the scenario is done.
+  hotelApi: async ({ playwright }, use) => {
+    const request = await playwright.request.newContext({
+      baseURL: process.env.API_URL || 'http://localhost:8080',
+    });
+    const hotelApi = new HotelApi(request);
+    await use(hotelApi);
+    await hotelApi.releaseClock();
+    await request.dispose();
+  },
 });
 
 // Export Given, When, Then, Before from fixtures using createBdd
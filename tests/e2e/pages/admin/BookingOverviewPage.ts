@@ -64,6 +64,34 @@ export default class BookingOverviewPage {
     await button.click();
   }
 
+  // settleBalanceIfOwing takes payment for the folio balance when check-out
+  // was refused because money is still owing.
+  async settleBalanceIfOwing(bookingId: string): Promise<void> {
+    const item = this.page.locator(`.booking-item[data-id="${bookingId}"]`);
+    const settleButton = item.locator('.settle-button');
+    const checkedOut = item.locator('.status', { hasText: 'Checked-out' });
+    await settleButton.or(checkedOut).first().waitFor({ state: 'visible' });
+    if (await settleButton.isVisible()) {
+      await settleButton.click();
+    }
+  }
+
+  // getSettleButton is shown once check-out is refused for a balance owing;
+  // its label carries the amount to take.
+  getSettleButton(bookingId: string): Locator {
+    return this.page.locator(`.booking-item[data-id="${bookingId}"] .settle-button`);
+  }
+
+  async takePaymentAndCheckOut(bookingId: string): Promise<void> {
+    await this.getSettleButton(bookingId).click();
+  }
+
+  async overrideCheckOut(bookingId: string, reason: string): Promise<void> {
+    const item = this.page.locator(`.booking-item[data-id="${bookingId}"]`);
+    await item.locator('input[name="overrideReason"]').fill(reason);
+    await item.locator('.override-check-out-button').click();
+  }
+
   async getBookingList(): Promise<Locator[]> {
     return await this.bookingList.all();
   }
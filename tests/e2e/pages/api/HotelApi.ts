@@ -0,0 +1,220 @@
+import type { APIRequestContext, APIResponse } from '@playwright/test';
+
+export interface Fee {
+  id: string;
+  kind: string;
+  amount: number;
+  waived: boolean;
+}
+
+export interface Installment {
+  id: string;
+  description: string;
+  amount: number;
+  outstanding: number;
+  dueAt: string;
+}
+
+export interface Booking {
+  id: string;
+  confirmationCode: string;
+  roomId: string;
+  roomType: string;
+  checkIn: string;
+  checkOut: string;
+  status: string;
+  noShowFee?: number;
+  fees?: Fee[];
+  payment?: { status: string; authorized: number; captured: number; refunded: number };
+  schedule?: Installment[];
+  depositOverdue?: boolean;
+}
+
+export interface Folio {
+  bookingId: string;
+  lines: { id: string; kind: string; description: string; amount: number; night?: string }[];
+  paid: number;
+  balance: number;
+}
+
+export interface AuditReport {
+  date: string;
+  roomsAvailable: number;
+  roomsOccupied: number;
+  occupancy: number;
+  roomNightsPosted: number;
+  noShows: string[];
+  uncheckedDepartures: string[];
+  roomRevenue: number;
+}
+
+export interface BusinessDate {
+  businessDate: string;
+  calendarDate: string;
+  now: string;
+}
+
+export interface JobResult {
+  processed: number;
+  bookings: Booking[];
+}
+
+export interface RoomResult {
+  id: string;
+  type: string;
+}
+
+interface NewBooking {
+  roomType: string;
+  checkIn: string;
+  checkOut: string;
+  guests?: number;
+}
+
+/**
+ * HotelApi drives the hotel API directly for set-up and for the back-office
+ * work that has no screen yet: the business clock, the scheduled jobs and
+ * the folio. It signs in as the front desk admin and as the seeded guest.
+ */
+export default class HotelApi {
+  readonly request: APIRequestContext;
+  private adminToken?: string;
+  private guestToken?: string;
+  private clockPinned = false;
+
+  constructor(request: APIRequestContext) {
+    this.request = request;
+  }
+
+  // pinClock moves the hotel clock to now (YYYY-MM-DDTHH:MM in UTC) and the
+  // business date to the same calendar day unless one is given. The server
+  // must run with BUSINESS_DATE_TEST_MODE=true.
+  async pinClock(now: string, businessDate?: string): Promise<BusinessDate> {
+    const res = await this.request.put('/api/admin/business-date', {
+      headers: await this.adminHeaders(),
+      data: { now: `${now}:00Z`, businessDate: businessDate ?? '' },
+    });
+    this.clockPinned = true;
+    return (await this.json(res)) as BusinessDate;
+  }
+
+  async businessDate(): Promise<BusinessDate> {
+    const res = await this.request.get('/api/admin/business-date', { headers: await this.adminHeaders() });
+    return (await this.json(res)) as BusinessDate;
+  }
+
+  // releaseClock puts a pinned clock back at the current time so scenarios
+  // that follow see today's date again.
+  async releaseClock(): Promise<void> {
+    if (!this.clockPinned) return;
+    await this.request.put('/api/admin/business-date', {
+      headers: await this.adminHeaders(),
+      data: { now: new Date().toISOString().replace(/\.\d+Z$/, 'Z') },
+    });
+    this.clockPinned = false;
+  }
+
+  async book(booking: NewBooking): Promise<Booking> {
+    const res = await this.request.post('/api/guest/bookings', {
+      headers: await this.guestHeaders(),
+      data: { userId: 'user-guest-1', guests: 1, ...booking },
+    });
+    return (await this.json(res)) as Booking;
+  }
+
+  async cancel(id: string): Promise<void> {
+    const res = await this.request.post(`/api/guest/bookings/${id}/cancel`, { headers: await this.guestHeaders() });
+    await this.json(res);
+  }
+
+  async payNextInstallment(id: string): Promise<Booking> {
+    const res = await this.request.post(`/api/guest/bookings/${id}/pay`, { headers: await this.guestHeaders() });
+    return (await this.json(res)) as Booking;
+  }
+
+  // findBooking looks a booking up through the admin search, which unlike
+  // the guest detail still returns cancelled and no-show bookings.
+  async findBooking(confirmationCode: string): Promise<Booking> {
+    const res = await this.request.get('/api/admin/bookings', {
+      headers: await this.adminHeaders(),
+      params: { code: confirmationCode },
+    });
+    const { bookings } = (await this.json(res)) as { bookings: Booking[] | null };
+    const booking = bookings?.[0];
+    if (!booking) {
+      throw new Error(`No booking with confirmation code ${confirmationCode}`);
+    }
+    return booking;
+  }
+
+  async checkIn(id: string, actionDate: string): Promise<Booking> {
+    const res = await this.request.post(`/api/admin/bookings/${id}/check-in`, {
+      headers: await this.adminHeaders(),
+      params: { actionDate },
+    });
+    return (await this.json(res)) as Booking;
+  }
+
+  async postCharge(id: string, description: string, amount: number): Promise<Folio> {
+    const res = await this.request.post(`/api/admin/bookings/${id}/folio`, {
+      headers: await this.adminHeaders(),
+      data: { kind: 'charge', description, amount },
+    });
+    return (await this.json(res)) as Folio;
+  }
+
+  async folio(id: string): Promise<Folio> {
+    const res = await this.request.get(`/api/admin/bookings/${id}/folio`, { headers: await this.adminHeaders() });
+    return (await this.json(res)) as Folio;
+  }
+
+  async runNoShows(): Promise<JobResult> {
+    return await this.runJob('no-shows');
+  }
+
+  async runDeposits(): Promise<JobResult> {
+    return await this.runJob('deposits');
+  }
+
+  async runNightAudit(): Promise<AuditReport> {
+    const res = await this.request.post('/api/admin/jobs/night-audit', { headers: await this.adminHeaders() });
+    return (await this.json(res)) as AuditReport;
+  }
+
+  async searchRooms(checkIn: string, checkOut: string, guests = 1): Promise<RoomResult[]> {
+    const res = await this.request.get('/api/guest/rooms/search', {
+      params: { checkIn, checkOut, guests: String(guests) },
+    });
+    const { rooms } = (await this.json(res)) as { rooms: RoomResult[] | null };
+    return rooms ?? [];
+  }
+
+  private async runJob(name: string): Promise<JobResult> {
+    const res = await this.request.post(`/api/admin/jobs/${name}`, { headers: await this.adminHeaders() });
+    return (await this.json(res)) as JobResult;
+  }
+
+  private async adminHeaders(): Promise<Record<string, string>> {
+    this.adminToken ??= await this.login('/api/admin/auth/login', 'admin@stayflex.test', 'admin123');
+    return { Authorization: `Bearer ${this.adminToken}` };
+  }
+
+  private async guestHeaders(): Promise<Record<string, string>> {
+    this.guestToken ??= await this.login('/api/auth/login', 'guest1@stayflex.test', 'password123');
+    return { Authorization: `Bearer ${this.guestToken}` };
+  }
+
+  private async login(path: string, email: string, password: string): Promise<string> {
+    const res = await this.request.post(path, { data: { email, password } });
+    const { token } = (await this.json(res)) as { token: string };
+    return token;
+  }
+
+  private async json(res: APIResponse): Promise<unknown> {
+    if (!res.ok()) {
+      throw new Error(`${res.url()} answered ${res.status()}: ${await res.text()}`);
+    }
+    if (res.status() === 204) return undefined;
+    return await res.json();
+  }
+}
@@ -8,6 +8,9 @@ const testDir = defineBddConfig({
 
 export default defineConfig({
   testDir,
+  // Scenarios share one hotel API and some of them pin its business clock,
+  // so they run one at a time.
+  workers: 1,
   reporter: [
         cucumberReporter('html', {
           outputFile: 'cucumber-report/index.html',
@@ -107,6 +107,10 @@ When('I mark it as {string}', async ({ bookingOverviewPage }, status: string) =>
   scenarioState.expectedStatus = status.replace('‑', '-');
 });
 
+When('I settle any outstanding balance', async ({ bookingOverviewPage }) => {
+  await bookingOverviewPage.settleBalanceIfOwing(scenarioState.selectedBookingId!);
+});
+
 Then('the booking status should be updated to {string}', async ({ bookingOverviewPage }, status: string) => {
   const bookingId = scenarioState.selectedBookingId!;
   const currentStatus = await bookingOverviewPage.getStatusByBookingId(bookingId);
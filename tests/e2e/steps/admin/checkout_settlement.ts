@@ -0,0 +1,60 @@
+import { expect } from '@playwright/test';
+import { Before, Given, When, Then } from '../../fixtures';
+import type { Booking } from '../../pages/api/HotelApi';
+
+interface ScenarioState {
+  booking?: Booking;
+}
+
+let scenarioState: ScenarioState = {};
+
+Before(() => {
+  scenarioState = {};
+});
+
+Given('a guest is checked in to a {string} room from {string} to {string}', async ({ hotelApi }, roomType: string, checkIn: string, checkOut: string) => {
+  const booking = await hotelApi.book({ roomType, checkIn, checkOut });
+  scenarioState.booking = await hotelApi.checkIn(booking.id, checkIn);
+});
+
+Given('a {string} charge of {int} is on the folio', async ({ hotelApi }, description: string, amount: number) => {
+  await hotelApi.postCharge(scenarioState.booking!.id, description, amount);
+});
+
+When('I check out the in-house booking', async ({ bookingOverviewPage }) => {
+  await bookingOverviewPage.waitForBookingList();
+  await bookingOverviewPage.markCheckedOut(scenarioState.booking!.id);
+});
+
+Then('I should be asked to take a payment of {string}', async ({ bookingOverviewPage }, amount: string) => {
+  const settleButton = bookingOverviewPage.getSettleButton(scenarioState.booking!.id);
+  await expect(settleButton).toBeVisible();
+  await expect(settleButton).toContainText(amount);
+});
+
+When('I take the payment and check out', async ({ bookingOverviewPage }) => {
+  await bookingOverviewPage.takePaymentAndCheckOut(scenarioState.booking!.id);
+});
+
+When('I override the check-out with the reason {string}', async ({ bookingOverviewPage }, reason: string) => {
+  await bookingOverviewPage.overrideCheckOut(scenarioState.booking!.id, reason);
+});
+
+// The page falls back to a local status change when the API call fails, so
+// the booking is also read back from the API.
+Then('the in-house booking should show as {string}', async ({ bookingOverviewPage, hotelApi }, status: string) => {
+  const statusLocator = bookingOverviewPage.getStatusLocatorByBookingId(scenarioState.booking!.id);
+  await expect(statusLocator).toContainText(status);
+  const booking = await hotelApi.findBooking(scenarioState.booking!.confirmationCode);
+  expect(booking.status).toBe(status);
+});
+
+Then('the folio should be settled', async ({ hotelApi }) => {
+  const folio = await hotelApi.folio(scenarioState.booking!.id);
+  expect(folio.balance).toBe(0);
+});
+
+Then('the folio should still owe {int}', async ({ hotelApi }, amount: number) => {
+  const folio = await hotelApi.folio(scenarioState.booking!.id);
+  expect(folio.balance).toBe(amount);
+});
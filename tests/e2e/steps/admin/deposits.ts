@@ -0,0 +1,60 @@
+import { expect } from '@playwright/test';
+import { Before, Given, When, Then } from '../../fixtures';
+import type { Booking, JobResult } from '../../pages/api/HotelApi';
+
+interface ScenarioState {
+  booking?: Booking;
+  job?: JobResult;
+}
+
+let scenarioState: ScenarioState = {};
+
+Before(() => {
+  scenarioState = {};
+});
+
+// Stays of five nights or more take a 30% deposit due three days after
+// booking (DEPOSIT_RULES in e2e.sh).
+When('a guest books a {string} room from {string} to {string}', async ({ hotelApi }, roomType: string, checkIn: string, checkOut: string) => {
+  scenarioState.booking = await hotelApi.book({ roomType, checkIn, checkOut });
+});
+
+Given('the guest pays the deposit', async ({ hotelApi }) => {
+  scenarioState.booking = await hotelApi.payNextInstallment(scenarioState.booking!.id);
+});
+
+When('the deposit job runs', async ({ hotelApi }) => {
+  scenarioState.job = await hotelApi.runDeposits();
+});
+
+Then('the booking should have a deposit of {int} due on {string}', async ({}, amount: number, dueDate: string) => {
+  const deposit = scenarioState.booking!.schedule?.find((installment) => installment.description === 'Deposit');
+  expect(deposit).toBeDefined();
+  expect(deposit!.amount).toBe(amount);
+  expect(deposit!.outstanding).toBe(amount);
+  expect(deposit!.dueAt.slice(0, 10)).toBe(dueDate);
+});
+
+Then('the booking should be {string} with its deposit overdue', async ({ hotelApi }, status: string) => {
+  const booking = await hotelApi.findBooking(scenarioState.booking!.confirmationCode);
+  expect(booking.status).toBe(status);
+  expect(booking.depositOverdue).toBe(true);
+});
+
+Then('the card authorization should be released', async ({ hotelApi }) => {
+  const booking = await hotelApi.findBooking(scenarioState.booking!.confirmationCode);
+  expect(booking.payment?.status).toBe('voided');
+});
+
+Then('the deposit job should not have processed the booking', async () => {
+  const ids = scenarioState.job!.bookings.map((booking) => booking.id);
+  expect(ids).not.toContain(scenarioState.booking!.id);
+});
+
+Then('the booking should be {string} with nothing left to pay on the deposit', async ({ hotelApi }, status: string) => {
+  const booking = await hotelApi.findBooking(scenarioState.booking!.confirmationCode);
+  expect(booking.status).toBe(status);
+  expect(booking.depositOverdue ?? false).toBe(false);
+  const deposit = booking.schedule?.find((installment) => installment.description === 'Deposit');
+  expect(deposit?.outstanding).toBe(0);
+});
@@ -0,0 +1,60 @@
+import { expect } from '@playwright/test';
+import { Before, Given, When, Then } from '../../fixtures';
+import type { AuditReport, Booking } from '../../pages/api/HotelApi';
+
+interface ScenarioState {
+  inHouse?: Booking;
+  arrival?: Booking;
+  report?: AuditReport;
+}
+
+let scenarioState: ScenarioState = {};
+
+Before(() => {
+  scenarioState = {};
+});
+
+Given('a guest is in house in a {string} room from {string} to {string}', async ({ hotelApi }, roomType: string, checkIn: string, checkOut: string) => {
+  const booking = await hotelApi.book({ roomType, checkIn, checkOut });
+  scenarioState.inHouse = await hotelApi.checkIn(booking.id, checkIn);
+});
+
+Given('a guest has an arrival in a {string} room on {string} who never checks in', async ({ hotelApi }, roomType: string, checkIn: string) => {
+  const [year, month, day] = checkIn.split('-').map(Number);
+  const checkOut = new Date(Date.UTC(year, month - 1, day + 1)).toISOString().slice(0, 10);
+  scenarioState.arrival = await hotelApi.book({ roomType, checkIn, checkOut });
+});
+
+When('the night audit runs', async ({ hotelApi }) => {
+  scenarioState.report = await hotelApi.runNightAudit();
+});
+
+Then('the audit report for {string} should show {int} room night(s) posted', async ({}, date: string, nights: number) => {
+  const report = scenarioState.report!;
+  expect(report.date).toBe(date);
+  expect(report.roomNightsPosted).toBe(nights);
+  expect(report.roomsOccupied).toBe(nights);
+});
+
+Then('the in-house folio should be charged {int} for the night of {string}', async ({ hotelApi }, amount: number, night: string) => {
+  const folio = await hotelApi.folio(scenarioState.inHouse!.id);
+  const roomLines = folio.lines.filter((line) => line.kind === 'room' && line.night === night);
+  expect(roomLines).toHaveLength(1);
+  expect(roomLines[0].amount).toBe(amount);
+});
+
+Then('the arrival should be reported as a no-show', async ({ hotelApi }) => {
+  expect(scenarioState.report!.noShows).toContain(scenarioState.arrival!.id);
+  const booking = await hotelApi.findBooking(scenarioState.arrival!.confirmationCode);
+  expect(booking.status).toBe('no-show');
+});
+
+Then('the business date should be {string}', async ({ hotelApi }, date: string) => {
+  const status = await hotelApi.businessDate();
+  expect(status.businessDate).toBe(date);
+});
+
+Then('the in-house folio should have {int} room charge(s)', async ({ hotelApi }, count: number) => {
+  const folio = await hotelApi.folio(scenarioState.inHouse!.id);
+  expect(folio.lines.filter((line) => line.kind === 'room')).toHaveLength(count);
+});
@@ -0,0 +1,53 @@
+import { expect } from '@playwright/test';
+import { Before, Given, When, Then } from '../../fixtures';
+import type { Booking, JobResult } from '../../pages/api/HotelApi';
+
+interface ScenarioState {
+  booking?: Booking;
+  job?: JobResult;
+}
+
+let scenarioState: ScenarioState = {};
+
+Before(() => {
+  scenarioState = {};
+});
+
+Given('a guest has booked a {string} from {string} to {string}', async ({ hotelApi }, roomType: string, checkIn: string, checkOut: string) => {
+  scenarioState.booking = await hotelApi.book({ roomType, checkIn, checkOut });
+});
+
+Given('no {string} can be booked from {string} to {string}', async ({ hotelApi }, roomType: string, checkIn: string, checkOut: string) => {
+  const rooms = await hotelApi.searchRooms(checkIn, checkOut);
+  expect(rooms.map((room) => room.type)).not.toContain(roomType);
+});
+
+When('the no-show job runs', async ({ hotelApi }) => {
+  scenarioState.job = await hotelApi.runNoShows();
+});
+
+Then('the booking should be marked as a no-show', async ({ hotelApi }) => {
+  const booking = await hotelApi.findBooking(scenarioState.booking!.confirmationCode);
+  expect(booking.status).toBe('no-show');
+});
+
+Then('its card authorization should be voided', async ({ hotelApi }) => {
+  const booking = await hotelApi.findBooking(scenarioState.booking!.confirmationCode);
+  expect(booking.payment?.status).toBe('voided');
+  expect(booking.payment?.captured).toBe(0);
+});
+
+Then('a {string} can be booked from {string} to {string}', async ({ hotelApi }, roomType: string, checkIn: string, checkOut: string) => {
+  const rooms = await hotelApi.searchRooms(checkIn, checkOut);
+  expect(rooms.map((room) => room.type)).toContain(roomType);
+});
+
+Then('the no-show job should not have processed the booking', async () => {
+  const ids = scenarioState.job!.bookings.map((booking) => booking.id);
+  expect(ids).not.toContain(scenarioState.booking!.id);
+});
+
+Then('the booking should still be {string}', async ({ hotelApi }, status: string) => {
+  const booking = await hotelApi.findBooking(scenarioState.booking!.confirmationCode);
+  expect(booking.status).toBe(status);
+});
@@ -0,0 +1,12 @@
+import { Given } from '../../fixtures';
+
+// The clock is read as YYYY-MM-DDTHH:MM in UTC. Pinning it needs the API to
+// run with BUSINESS_DATE_TEST_MODE=true; the hotelApi fixture hands it back
+// at the current time after the scenario.
+Given('the hotel clock reads {string}', async ({ hotelApi }, now: string) => {
+  await hotelApi.pinClock(now);
+});
+
+Given('the hotel clock reads {string} on business date {string}', async ({ hotelApi }, now: string, businessDate: string) => {
+  await hotelApi.pinClock(now, businessDate);
+});
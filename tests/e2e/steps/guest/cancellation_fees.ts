@@ -0,0 +1,49 @@
+import { expect } from '@playwright/test';
+import { Before, Given, When, Then } from '../../fixtures';
+import type { Booking } from '../../pages/api/HotelApi';
+
+interface ScenarioState {
+  booking?: Booking;
+}
+
+let scenarioState: ScenarioState = {};
+
+Before(() => {
+  scenarioState = {};
+});
+
+// Bookings cancel free of charge until 48 hours before the standard
+// check-in time; later cancellations pay one night.
+Given('I hold a {string} booking from {string} to {string}', async ({ hotelApi }, roomType: string, checkIn: string, checkOut: string) => {
+  scenarioState.booking = await hotelApi.book({ roomType, checkIn, checkOut });
+});
+
+When('I cancel that booking', async ({ myBookingsPage }) => {
+  const bookingId = scenarioState.booking!.id;
+  await myBookingsPage.cancelBookingById(bookingId);
+  await expect(myBookingsPage.getBookingLocatorById(bookingId)).toBeHidden();
+});
+
+Then('the booking should be cancelled without a fee', async ({ hotelApi }) => {
+  const booking = await hotelApi.findBooking(scenarioState.booking!.confirmationCode);
+  expect(booking.status).toBe('cancelled');
+  expect(booking.fees ?? []).toHaveLength(0);
+});
+
+Then('my card authorization should be voided', async ({ hotelApi }) => {
+  const booking = await hotelApi.findBooking(scenarioState.booking!.confirmationCode);
+  expect(booking.payment?.status).toBe('voided');
+});
+
+Then('the booking should be cancelled with a cancellation fee of {int}', async ({ hotelApi }, amount: number) => {
+  const booking = await hotelApi.findBooking(scenarioState.booking!.confirmationCode);
+  expect(booking.status).toBe('cancelled');
+  const fees = (booking.fees ?? []).filter((fee) => fee.kind === 'cancellation');
+  expect(fees).toHaveLength(1);
+  expect(fees[0].amount).toBe(amount);
+});
+
+Then('{int} should be captured from my card', async ({ hotelApi }, amount: number) => {
+  const booking = await hotelApi.findBooking(scenarioState.booking!.confirmationCode);
+  expect(booking.payment?.captured).toBe(amount);
+});